@@ -36,6 +36,18 @@ func (r AccessPolicy) GetPolicy() SubjectPolicy {
 	return r.Spec.SubjectPolicy
 }
 
+func (r *AccessPolicy) GetStatus() *AccessPolicyStatus {
+	return &r.Status
+}
+
+func (r *AccessPolicy) SetStatus(status *AccessPolicyStatus) {
+	r.Status = *status
+}
+
+func (r AccessPolicy) GetNamespaceSelector() *metav1.LabelSelector {
+	return nil
+}
+
 // AccessPolicySpec defines the desired state of AccessPolicy
 type AccessPolicySpec struct {
 	SubjectPolicy `json:",inline"`
@@ -43,6 +55,16 @@ type AccessPolicySpec struct {
 
 // AccessPolicyStatus defines the observed state of AccessPolicy.
 type AccessPolicyStatus struct {
+	// Conditions report whether the policy is valid and can match requests
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ActiveGrants is the number of unexpired grants authorized by the policy
+	ActiveGrants int `json:"activeGrants,omitempty"`
+	// PendingRequests is the number of requests resolved to the policy awaiting approval
+	PendingRequests int `json:"pendingRequests,omitempty"`
 }
 
 // +kubebuilder:object:root=true
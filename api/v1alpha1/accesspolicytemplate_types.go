@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessPolicyTemplateSpec defines the policy fields shared by the policies referencing the template
+type AccessPolicyTemplateSpec struct {
+	// AllowedRoles is a list of roles the subject is allowed to request.
+	// +optional
+	AllowedRoles []rbacv1.RoleRef `json:"allowedRoles,omitempty"`
+
+	// AllowedPermissions is a list of adhoc permissions the subject is allowed to request.
+	// +optional
+	AllowedPermissions []rbacv1.PolicyRule `json:"allowedPermissions,omitempty"`
+
+	// Duration specifies the maximum amount of time the access can last (e.g. "5s", "10m", "2h45m").
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	// +optional
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// The users and groups allowed to approve requests
+	// +optional
+	Approvers []rbacv1.Subject `json:"approvers,omitempty"`
+}
+
+// AccessPolicyTemplateStatus defines the observed state of AccessPolicyTemplate.
+type AccessPolicyTemplateStatus struct{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// AccessPolicyTemplate is the Schema for the accesspolicytemplates API
+type AccessPolicyTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of AccessPolicyTemplate
+	// +required
+	Spec AccessPolicyTemplateSpec `json:"spec"`
+
+	// status defines the observed state of AccessPolicyTemplate
+	// +optional
+	Status AccessPolicyTemplateStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessPolicyTemplateList contains a list of AccessPolicyTemplate
+type AccessPolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []AccessPolicyTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccessPolicyTemplate{}, &AccessPolicyTemplateList{})
+}
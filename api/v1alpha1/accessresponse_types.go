@@ -28,6 +28,10 @@ func (r *AccessResponse) GetApprover() string {
 	return r.Spec.Approver
 }
 
+func (r *AccessResponse) GetReason() string {
+	return r.Spec.Reason
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
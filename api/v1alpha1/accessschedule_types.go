@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *AccessSchedule) GetSpec() *AccessScheduleBaseSpec {
+	return &r.Spec.AccessScheduleBaseSpec
+}
+func (r *AccessSchedule) GetStatus() *AccessScheduleStatus {
+	return &r.Status
+}
+func (r *AccessSchedule) SetStatus(status *AccessScheduleStatus) {
+	r.Status = *status
+}
+func (r *AccessSchedule) GetScope() RequestScope {
+	return RequestScopeNamespace
+}
+
+// AccessScheduleSpec defines the desired state of AccessSchedule
+type AccessScheduleSpec struct {
+	AccessScheduleBaseSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// AccessSchedule is the Schema for the accessschedules API
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="Next-Occurrence",type=string,JSONPath=`.status.nextOccurrence`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type AccessSchedule struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of AccessSchedule
+	// +required
+	Spec AccessScheduleSpec `json:"spec"`
+
+	// status defines the observed state of AccessSchedule
+	// +optional
+	Status AccessScheduleStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessScheduleList contains a list of AccessSchedule
+type AccessScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []AccessSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccessSchedule{}, &AccessScheduleList{})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *BreakGlassReview) GetReviewer() string {
+	return r.Spec.Reviewer
+}
+
+func (r *BreakGlassReview) GetOutcome() ReviewOutcome {
+	return r.Spec.Outcome
+}
+
+func (r *BreakGlassReview) GetComment() string {
+	return r.Spec.Comment
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// BreakGlassReview is the Schema for the breakglassreviews API
+type BreakGlassReview struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of BreakGlassReview
+	// +required
+	Spec BreakGlassReviewSpec `json:"spec"`
+
+	// status defines the observed state of BreakGlassReview
+	// +optional
+	Status BreakGlassReviewStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// BreakGlassReviewList contains a list of BreakGlassReview
+type BreakGlassReviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []BreakGlassReview `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BreakGlassReview{}, &BreakGlassReviewList{})
+}
@@ -36,9 +36,26 @@ func (r ClusterAccessPolicy) GetPolicy() SubjectPolicy {
 	return r.Spec.SubjectPolicy
 }
 
+func (r *ClusterAccessPolicy) GetStatus() *AccessPolicyStatus {
+	return &r.Status
+}
+
+func (r *ClusterAccessPolicy) SetStatus(status *AccessPolicyStatus) {
+	r.Status = *status
+}
+
+func (r ClusterAccessPolicy) GetNamespaceSelector() *metav1.LabelSelector {
+	return r.Spec.NamespaceSelector
+}
+
 // ClusterAccessPolicySpec defines the desired state of ClusterAccessPolicy
 type ClusterAccessPolicySpec struct {
 	SubjectPolicy `json:",inline"`
+
+	// NamespaceSelector makes the policy apply to namespaced AccessRequests in namespaces with matching labels
+	// instead of ClusterAccessRequests. Access is granted with a RoleBinding in the request's namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -28,6 +28,10 @@ func (r *ClusterAccessResponse) GetApprover() string {
 	return r.Spec.Approver
 }
 
+func (r *ClusterAccessResponse) GetReason() string {
+	return r.Spec.Reason
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -5,6 +5,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// GrantExtension records an approved extension of a grant
+type GrantExtension struct {
+	// Request is the name of the request that extended the grant
+	Request string `json:"request"`
+	// Duration the grant was extended by
+	Duration   string      `json:"duration"`
+	ApprovedBy []string    `json:"approvedBy,omitempty"`
+	ExtendedAt metav1.Time `json:"extendedAt"`
+}
+
+// GrantRevocation records who ended a grant before it expired
+type GrantRevocation struct {
+	// RevokedBy is the user who revoked the grant
+	RevokedBy string `json:"revokedBy"`
+	// Reason given for the revocation
+	Reason string `json:"reason,omitempty"`
+	// Released is true when the subject gave up the grant themselves
+	Released  bool        `json:"released,omitempty"`
+	RevokedAt metav1.Time `json:"revokedAt"`
+}
+
 type AccessGrantStatus struct {
 	Request   string `json:"request"`
 	RequestId string `json:"requestId"`
@@ -12,12 +33,51 @@ type AccessGrantStatus struct {
 	Subject    string   `json:"subject"`
 	ApprovedBy []string `json:"approvedBy"`
 
+	// GrantTarget is the Group or ServiceAccount bound instead of the subject
+	GrantTarget *rbacv1.Subject `json:"grantTarget,omitempty"`
+
+	// Delegation is set for grants requested on behalf of another user
+	Delegation *DelegationStatus `json:"delegation,omitempty"`
+
+	// Incident is set for break-glass grants, which are created without approvals
+	Incident string `json:"incident,omitempty"`
+
+	// IncidentMode is the incident the grant was created or extended under
+	IncidentMode string `json:"incidentMode,omitempty"`
+
+	// Policy is the name of the policy that authorized the grant
+	Policy      string      `json:"policy,omitempty"`
+	PolicyScope PolicyScope `json:"policyScope,omitempty"`
+
 	Role        rbacv1.RoleRef      `json:"role,omitempty"`
 	Permissions []rbacv1.PolicyRule `json:"permissions,omitempty"`
 	Duration    string              `json:"duration"`
 
+	Justification       string            `json:"justification,omitempty"`
+	JustificationFields map[string]string `json:"justificationFields,omitempty"`
+
+	Schedule *AccessWindow `json:"schedule,omitempty"`
+
+	// Extensions are the approved extensions of the grant, oldest first
+	Extensions []GrantExtension `json:"extensions,omitempty"`
+
+	// AccessSchedule is the name of the schedule the grant was created for
+	AccessSchedule string `json:"accessSchedule,omitempty"`
+
+	// StartAt is set for scheduled grants, whose bindings aren't created until then
+	StartAt metav1.Time `json:"startAt,omitempty"`
+
 	AccessExpiresAt         metav1.Time `json:"accessExpiresAt,omitempty"`
 	RoleBindingCreated      bool        `json:"roleBindingCreated,omitempty"`
 	AdhocRoleCreated        bool        `json:"adhocRoleCreated,omitempty"`
 	AdhocRoleBindingCreated bool        `json:"adhocRoleBindingCreated,omitempty"`
+
+	// RetainAfterExpiry is how long the grant is kept after it expires so that it
+	// counts towards the subject's quota (e.g. "168h").
+	RetainAfterExpiry string `json:"retainAfterExpiry,omitempty"`
+	// ExpiredAt is when access was revoked from a grant that is being retained
+	ExpiredAt metav1.Time `json:"expiredAt,omitempty"`
+
+	// Revocation is set when the grant was revoked or released before it expired
+	Revocation *GrantRevocation `json:"revocation,omitempty"`
 }
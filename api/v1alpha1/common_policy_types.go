@@ -2,33 +2,297 @@ package v1alpha1
 
 import (
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +kubebuilder:validation:Enum=Cluster;Namespace
 type PolicyScope string
 
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// HourRange is a range of time within a day, in 24-hour "HH:MM" format.
+// +kubebuilder:validation:XValidation:rule="self.start < self.end",message="start must be before end"
+type HourRange struct {
+	// Start is the time of day the range begins (inclusive)
+	// +required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the range ends (exclusive), "24:00" means midnight
+	// +required
+	// +kubebuilder:validation:Pattern=`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+	End string `json:"end"`
+}
+
+// AccessWindow restricts when access may be requested and held under a policy.
+type AccessWindow struct {
+	// Weekdays on which access is allowed. Every day is allowed when empty.
+	// +optional
+	// +listType=set
+	Weekdays []Weekday `json:"weekdays,omitempty"`
+
+	// Hours are the ranges within an allowed day during which access is allowed.
+	// The whole day is allowed when empty.
+	// +optional
+	Hours []HourRange `json:"hours,omitempty"`
+
+	// TimeZone is the IANA time zone the window is evaluated in (e.g. "Europe/London").
+	// +optional
+	// +kubebuilder:default:="UTC"
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// SubjectQuota limits how much access a single subject can hold under a policy.
+// Grants are counted per subject within the scope of the policy.
+type SubjectQuota struct {
+	// MaxActiveGrants is the maximum number of grants the subject can hold at the same time
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxActiveGrants int `json:"maxActiveGrants,omitempty"`
+
+	// MaxGrantsPerDay is the maximum number of grants the subject can receive in a rolling 24 hour period
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGrantsPerDay int `json:"maxGrantsPerDay,omitempty"`
+
+	// MaxGrantsPerWeek is the maximum number of grants the subject can receive in a rolling 7 day period
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGrantsPerWeek int `json:"maxGrantsPerWeek,omitempty"`
+
+	// Cooldown is how long the subject must wait after a grant expires before another request is accepted (e.g. "30m").
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	Cooldown string `json:"cooldown,omitempty"`
+}
+
+// PolicyCondition is a CEL expression that must evaluate to true for a request to match the policy.
+// The expression can use the following variables:
+// `request` (the request spec), `groups` (the requester's groups),
+// `namespaceObject` (the target namespace) and `now` (the current time).
+type PolicyCondition struct {
+	// Expression is the CEL expression to evaluate, e.g. `namespaceObject.metadata.labels["env"] != "prod" || duration(request.duration) <= duration("15m")`
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+
+	// Message is returned when the expression evaluates to false
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// JustificationField describes a field the requester must provide to justify a request.
+type JustificationField struct {
+	// Name of the field, e.g. "ticket"
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	Name string `json:"name"`
+
+	// Required fields must be provided with every request
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Pattern is a regular expression the value must match, e.g. "^INC-[0-9]+$"
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// MinLength is the minimum length of the value
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinLength int `json:"minLength,omitempty"`
+}
+
+// TicketValidation checks the ticket referenced by a request with the ticket validation endpoint
+// configured on the controller.
+type TicketValidation struct {
+	// Field is the justification field that holds the ticket reference
+	// +optional
+	// +kubebuilder:default:=ticket
+	Field string `json:"field,omitempty"`
+}
+
+// ApprovalStage is a step in an approval chain. Stages are approved in order.
+type ApprovalStage struct {
+	// Name of the stage, e.g. "team-lead"
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The users and groups allowed to approve requests in this stage
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Approvers []rbacv1.Subject `json:"approvers"`
+
+	// The minimum number of approvals required to complete the stage
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	RequiredApprovals int `json:"requiredApprovals"`
+}
+
+// GroupQuorum is the number of approvals required from the members of a group
+type GroupQuorum struct {
+	// Group whose members must approve the request
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+
+	// The minimum number of approvals required from members of the group
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	RequiredApprovals int `json:"requiredApprovals"`
+}
+
+// AutoApprovalRule approves matching requests without waiting for approvers.
+// A request matches the rule when it satisfies every criterion that is set.
+// +kubebuilder:validation:XValidation:rule="has(self.verbs) || has(self.maxDuration) || has(self.namespaceSelector) || has(self.conditions) || has(self.onCall)",message="at least one of verbs, maxDuration, namespaceSelector, conditions or onCall must be set"
+type AutoApprovalRule struct {
+	// Name of the rule, e.g. "read-only"
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Reason is recorded with the approval
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Verbs restricts the rule to requests for permissions using only these verbs.
+	// Requests for a role don't match rules with verbs.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+
+	// MaxDuration restricts the rule to requests of at most this duration
+	// +optional
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// NamespaceSelector restricts the rule to namespaced requests in matching namespaces
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Conditions are CEL expressions that must all evaluate to true
+	// +optional
+	// +listType=atomic
+	Conditions []PolicyCondition `json:"conditions,omitempty"`
+
+	// OnCall restricts the rule to subjects that are currently on call in a roster
+	// +optional
+	OnCall *OnCallRequirement `json:"onCall,omitempty"`
+}
+
+// OnCallSourceType is where an on-call roster is looked up
+// +kubebuilder:validation:Enum=ConfigMap;HTTP
+type OnCallSourceType string
+
+const (
+	// OnCallSourceConfigMap reads the roster from a ConfigMap in the controller's namespace
+	OnCallSourceConfigMap OnCallSourceType = "ConfigMap"
+	// OnCallSourceHTTP asks the on-call endpoint configured on the controller
+	OnCallSourceHTTP OnCallSourceType = "HTTP"
+)
+
+// OnCallRequirement requires the subject to be on call in a roster.
+type OnCallRequirement struct {
+	// Roster is the name of the on-call roster, e.g. "payments-primary".
+	// For the ConfigMap source it is the name of the ConfigMap holding the roster.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Roster string `json:"roster"`
+
+	// Source is where the roster is looked up
+	// +optional
+	// +kubebuilder:default:=ConfigMap
+	Source OnCallSourceType `json:"source,omitempty"`
+}
+
+// GrantExtensions allows active grants to be extended by requests that set `extends`.
+type GrantExtensions struct {
+	// MaxTotalDuration is the longest a grant can last including all of its extensions (e.g. "12h")
+	// +required
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	MaxTotalDuration string `json:"maxTotalDuration"`
+
+	// The minimum number of approvals required to extend a grant
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=1
+	RequiredApprovals int `json:"requiredApprovals"`
+
+	// The users and groups allowed to approve extensions.
+	// Defaults to the policy's approvers, including those of its approval stages and quorum groups.
+	// +optional
+	Approvers []rbacv1.Subject `json:"approvers,omitempty"`
+}
+
+// BreakGlassPolicy allows requests that set `breakGlass` to be granted
+// immediately, without approvals. The use of the access is reviewed afterwards.
+type BreakGlassPolicy struct {
+	// The users and groups allowed to review break-glass requests.
+	// Defaults to the policy's approvers, including those of its approval stages and quorum groups.
+	// +optional
+	Reviewers []rbacv1.Subject `json:"reviewers,omitempty"`
+}
+
 // SubjectPolicy defines access rules for a single subject (user/serviceaccount).
-// +kubebuilder:validation:XValidation:rule="self.requiredApprovals == 0 || self.approvers.size() >= 1",message="number of approvers must be greater than zero"
+// +kubebuilder:validation:XValidation:rule="self.requiredApprovals == 0 || has(self.approvalStages) || has(self.approvalQuorum) || has(self.template) || self.approvers.size() >= 1",message="number of approvers must be greater than zero"
 type SubjectPolicy struct {
 	// The priority of the policy
 	// +kubebuilder:default:=0
 	Priority int `json:"priority,omitempty"`
 
+	// Template is the name of an AccessPolicyTemplate providing defaults for the
+	// policy. Fields set on the policy override the template's.
+	// +optional
+	Template string `json:"template,omitempty"`
+
 	// The permitted users and groups that can request resources under this policy.
 	// +required
 	// +kubebuilder:validation:MinItems=1
 	Requesters []rbacv1.Subject `json:"requesters,omitempty"`
 
+	// AllowDelegation lists the users and groups allowed to request access on behalf of
+	// other users. The delegator is matched against AllowDelegation, and the user the access is
+	// requested for against Requesters.
+	// +optional
+	AllowDelegation []rbacv1.Subject `json:"allowDelegation,omitempty"`
+
 	// AllowedRoles is a list of roles the subject is allowed to request.
 	AllowedRoles []rbacv1.RoleRef `json:"allowedRoles,omitempty"`
 
 	// AllowedPermissions is a list of adhoc permissions the subject is allowed to request.
+	// Resources and ResourceNames can contain glob patterns, such as "payments-*" or "*/scale".
 	AllowedPermissions []rbacv1.PolicyRule `json:"allowedPermissions,omitempty"`
 
+	// AllowedGrantTargets are the Groups and ServiceAccounts access can be granted to
+	// instead of the requesting user. Names can contain glob patterns, such as "oncall-*".
+	// A ServiceAccount without a namespace matches service accounts in the request's namespace.
+	// +optional
+	AllowedGrantTargets []rbacv1.Subject `json:"allowedGrantTargets,omitempty"`
+
+	// DeniedRoles is a list of roles the subject can't request, even when allowed by AllowedRoles.
+	// +optional
+	DeniedRoles []rbacv1.RoleRef `json:"deniedRoles,omitempty"`
+
+	// DeniedPermissions is a list of permissions the subject can't request. A requested
+	// rule overlapping a denied rule is rejected, even when AllowedPermissions allows it.
+	// +optional
+	DeniedPermissions []rbacv1.PolicyRule `json:"deniedPermissions,omitempty"`
+
 	// Duration specifies the maximum amount of time the access can last (e.g. "5s", "10m", "2h45m").
+	// Required unless the policy's template sets it.
 	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
-	// +required
-	MaxDuration string `json:"maxDuration"`
+	// +optional
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// DefaultDuration is the duration of requests that don't set one (e.g. "30m").
+	// Requests default to 10m when neither the request nor the policy sets a duration.
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	// +optional
+	DefaultDuration string `json:"defaultDuration,omitempty"`
+
+	// PendingTimeout is how long a request can wait for approval before it expires (e.g. "12h").
+	// Defaults to 1h.
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	// +optional
+	PendingTimeout string `json:"pendingTimeout,omitempty"`
 
 	// The minimum number of approvals required to grant the request
 	// +kubebuilder:validation:Minimum=0
@@ -38,7 +302,63 @@ type SubjectPolicy struct {
 	// The users and groups allowed to approve requests for this subject
 	Approvers []rbacv1.Subject `json:"approvers,omitempty"`
 
+	// ApprovalStages require approvals in ordered stages, each with its own approvers.
+	// When set, Approvers and RequiredApprovals are ignored.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ApprovalStages []ApprovalStage `json:"approvalStages,omitempty"`
+
+	// ApprovalQuorum requires approvals from members of each of the given groups.
+	// Each approval is counted under a single group, so an approver in several
+	// groups can't satisfy the quorum for more than one of them.
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	ApprovalQuorum []GroupQuorum `json:"approvalQuorum,omitempty"`
+
+	// AutoApprovals approve requests matching any of the rules without waiting for approvers
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AutoApprovals []AutoApprovalRule `json:"autoApprovals,omitempty"`
+
 	// Allow the requester to approve their own requests
 	// +kubebuilder:default:=false
 	AllowSelfApproval bool `json:"allowSelfApproval,omitempty"`
+
+	// Schedule restricts access to the given weekdays and hours.
+	// Requests made outside the window are rejected, and grants are revoked when the window ends.
+	// +optional
+	Schedule *AccessWindow `json:"schedule,omitempty"`
+
+	// Quota limits the number of grants a subject can hold or receive under this policy.
+	// +optional
+	Quota *SubjectQuota `json:"quota,omitempty"`
+
+	// Extensions allows the subject to extend an active grant under this policy.
+	// Grants can't be extended when unset.
+	// +optional
+	Extensions *GrantExtensions `json:"extensions,omitempty"`
+
+	// BreakGlass allows the subject to get emergency access without approvals.
+	// Break-glass requests are granted immediately and must be reviewed afterwards.
+	// +optional
+	BreakGlass *BreakGlassPolicy `json:"breakGlass,omitempty"`
+
+	// Conditions are CEL expressions that must all evaluate to true for a request to match the policy.
+	// +optional
+	// +listType=atomic
+	Conditions []PolicyCondition `json:"conditions,omitempty"`
+
+	// JustificationSchema defines the fields a request must provide as its justification.
+	// When set, requests can only provide the fields listed in the schema.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	JustificationSchema []JustificationField `json:"justificationSchema,omitempty"`
+
+	// TicketValidation requires requests to reference a ticket that exists, is open and is assigned to the requester.
+	// +optional
+	TicketValidation *TicketValidation `json:"ticketValidation,omitempty"`
 }
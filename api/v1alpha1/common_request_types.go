@@ -13,7 +13,7 @@ const (
 	RequestScopeNamespace RequestScope = "Namespace"
 )
 
-// +kubebuilder:validation:Enum=Pending;Approved;Denied;Expired
+// +kubebuilder:validation:Enum=Pending;Approved;Denied;Expired;PendingReview
 type RequestState string
 
 const (
@@ -21,11 +21,34 @@ const (
 	RequestStateApproved RequestState = "Approved"
 	RequestStateDenied   RequestState = "Denied"
 	RequestStateExpired  RequestState = "Expired"
+	// RequestStatePendingReview is the state of break-glass requests that were
+	// granted without approvals and haven't been reviewed yet
+	RequestStatePendingReview RequestState = "PendingReview"
 )
 
 type AccessRequestApproval struct {
 	Approver   string      `json:"approver"`
 	ApprovedAt metav1.Time `json:"approvedAt"`
+	// Group is the quorum group the approval was counted under
+	Group string `json:"group,omitempty"`
+	// Reason is set for approvals made by an auto-approval rule
+	Reason string `json:"reason,omitempty"`
+	// OnCall is the roster entry that justified an on-call auto-approval
+	OnCall *OnCallEntry `json:"onCall,omitempty"`
+}
+
+// OnCallEntry is a shift in an on-call roster.
+type OnCallEntry struct {
+	// Roster the entry belongs to
+	Roster string `json:"roster"`
+	// Source the roster was looked up from
+	Source OnCallSourceType `json:"source,omitempty"`
+	// User that is on call
+	User string `json:"user"`
+	// Start of the shift
+	Start metav1.Time `json:"start"`
+	// End of the shift
+	End metav1.Time `json:"end"`
 }
 
 type AccessRequestBaseSpec struct {
@@ -34,7 +57,25 @@ type AccessRequestBaseSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Subject cannot be changed after creation"
 	Subject string `json:"subject"`
 
-	// Groups are the groups the subject belongs to
+	// OnBehalfOf is the user the access is requested for, when requesting access for someone
+	// else. The request's subject is set to this user and the requester is recorded as the delegator.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OnBehalfOf cannot be changed after creation"
+	OnBehalfOf string `json:"onBehalfOf,omitempty"`
+
+	// Delegator is the user who requested access on behalf of the subject
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Delegator cannot be changed after creation"
+	Delegator string `json:"delegator,omitempty"`
+
+	// DelegatorGroups are the groups the delegator belongs to
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="DelegatorGroups cannot be changed after creation"
+	DelegatorGroups []string `json:"delegatorGroups,omitempty"`
+
+	// Groups are the groups the subject belongs to. They're empty for delegated requests,
+	// since the groups of the user the access is requested for aren't known.
 	// +optional
 	// +listType=set
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Groups cannot be changed after creation"
@@ -50,15 +91,92 @@ type AccessRequestBaseSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Permissions cannot be changed after creation"
 	Permissions []rbacv1.PolicyRule `json:"permissions,omitempty"`
 
+	// GrantTarget is the User, Group or ServiceAccount the access is granted to.
+	// Defaults to the subject. A Group must be one of the subject's groups, and
+	// the policy must allow Groups and ServiceAccounts in its allowedGrantTargets.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="GrantTarget cannot be changed after creation"
+	GrantTarget *rbacv1.Subject `json:"grantTarget,omitempty"`
+
+	// Extends is the name of an active grant of the subject to extend by Duration.
+	// The grant's role, permissions and target are requested again, and the
+	// extension is approved under the policy that authorized the grant.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Extends cannot be changed after creation"
+	Extends string `json:"extends,omitempty"`
+
+	// BreakGlass requests emergency access, granted immediately without approvals
+	// when the matched policy allows it. The request must be reviewed afterwards.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="BreakGlass cannot be changed after creation"
+	BreakGlass *BreakGlassRequest `json:"breakGlass,omitempty"`
+
+	// AccessSchedule is set on the request created to approve an AccessSchedule.
+	// Approving the request approves the schedule instead of creating a grant.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="AccessSchedule cannot be changed after creation"
+	AccessSchedule string `json:"accessSchedule,omitempty"`
+
+	// StartAt schedules the access to start at a later time. The request can be
+	// approved in advance, and the grant's bindings are created at StartAt.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="StartAt cannot be changed after creation"
+	StartAt *metav1.Time `json:"startAt,omitempty"`
+
 	// Duration specifies how long the access should last (e.g. "5s", "10m", "2h45m").
+	// Defaults to the matched policy's defaultDuration.
+	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Duration cannot be changed after creation"
 	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
-	// +kubebuilder:default:="10m"
-	Duration string `json:"duration"`
+	Duration string `json:"duration,omitempty"`
 
 	// User's justification for the request
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Justification cannot be changed after creation"
+	// +kubebuilder:validation:MinLength=1
 	Justification string `json:"justification"`
+
+	// JustificationFields are the structured justification fields required by the policy's justification schema
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="JustificationFields cannot be changed after creation"
+	JustificationFields map[string]string `json:"justificationFields,omitempty"`
+}
+
+// BreakGlassRequest is an emergency request for access
+type BreakGlassRequest struct {
+	// Incident is a reference to the incident the access is needed for
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Incident string `json:"incident"`
+}
+
+// BreakGlassReviewRecord is the outcome of the review of a break-glass request
+type BreakGlassReviewRecord struct {
+	Reviewer   string        `json:"reviewer"`
+	Outcome    ReviewOutcome `json:"outcome"`
+	Comment    string        `json:"comment,omitempty"`
+	ReviewedAt metav1.Time   `json:"reviewedAt"`
+}
+
+// TicketStatus is the ticket a request was validated against
+type TicketStatus struct {
+	Reference string `json:"reference"`
+	URL       string `json:"url,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// DelegationStatus records who requested access on behalf of whom
+type DelegationStatus struct {
+	Delegator   string `json:"delegator"`
+	Beneficiary string `json:"beneficiary"`
+}
+
+// ApprovalStageStatus is the progress of a request through an approval stage
+type ApprovalStageStatus struct {
+	Name              string      `json:"name"`
+	ApprovalsRequired int         `json:"approvalsRequired"`
+	ApprovalsReceived int         `json:"approvalsReceived,omitempty"`
+	ApprovedBy        []string    `json:"approvedBy,omitempty"`
+	CompletedAt       metav1.Time `json:"completedAt,omitempty"`
 }
 
 type AccessRequestStatus struct {
@@ -68,8 +186,25 @@ type AccessRequestStatus struct {
 	ApprovalsReceived int          `json:"approvalsReceived,omitempty"`
 	RequestExpiresAt  metav1.Time  `json:"requestExpiresAt,omitempty"`
 	ResolvedPolicy    string       `json:"resolvedPolicy,omitempty"`
+	// ResolvedPolicyScope tells whether ResolvedPolicy is an AccessPolicy or a ClusterAccessPolicy
+	ResolvedPolicyScope PolicyScope `json:"resolvedPolicyScope,omitempty"`
 
 	Approvals []AccessRequestApproval `json:"approvals,omitempty"`
 
+	// CurrentStage is the approval stage awaiting approval
+	CurrentStage string                `json:"currentStage,omitempty"`
+	Stages       []ApprovalStageStatus `json:"stages,omitempty"`
+
+	Ticket *TicketStatus `json:"ticket,omitempty"`
+
+	// Delegation is set for requests made on behalf of another user
+	Delegation *DelegationStatus `json:"delegation,omitempty"`
+
+	// Review is the review of a break-glass request
+	Review *BreakGlassReviewRecord `json:"review,omitempty"`
+
+	// IncidentMode is the active incident whose overrides applied to the matched policy
+	IncidentMode string `json:"incidentMode,omitempty"`
+
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -1,11 +1,13 @@
 package v1alpha1
 
-// +kubebuilder:validation:Enum=Approved;Denied
+// +kubebuilder:validation:Enum=Approved;Denied;Revoked
 type ResponseState string
 
 const (
 	ResponseStateApproved ResponseState = "Approved"
 	ResponseStateDenied   ResponseState = "Denied"
+	// ResponseStateRevoked ends the grant of an approved request before it expires
+	ResponseStateRevoked ResponseState = "Revoked"
 )
 
 type AccessResponseSpec struct {
@@ -26,6 +28,11 @@ type AccessResponseSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Response cannot be changed after creation"
 	// +required
 	Response ResponseState `json:"response"`
+
+	// Reason explains the response, and is recorded on the grant when it is revoked
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Reason cannot be changed after creation"
+	Reason string `json:"reason,omitempty"`
 }
 
 type AccessResponseStatus struct {
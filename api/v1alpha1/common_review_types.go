@@ -0,0 +1,39 @@
+package v1alpha1
+
+// +kubebuilder:validation:Enum=Acceptable;Flagged
+type ReviewOutcome string
+
+const (
+	// ReviewOutcomeAcceptable marks the use of break-glass access as justified
+	ReviewOutcomeAcceptable ReviewOutcome = "Acceptable"
+	// ReviewOutcomeFlagged marks the use of break-glass access for follow-up
+	ReviewOutcomeFlagged ReviewOutcome = "Flagged"
+)
+
+type BreakGlassReviewSpec struct {
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="RequestRef cannot be changed after creation"
+	// +required
+	RequestRef string `json:"requestRef"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Reviewer cannot be changed after creation"
+	// +required
+	Reviewer string `json:"reviewer"`
+
+	// Groups are the groups the reviewer belongs to
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Groups cannot be changed after creation"
+	Groups []string `json:"groups,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Outcome cannot be changed after creation"
+	// +required
+	Outcome ReviewOutcome `json:"outcome"`
+
+	// Comment explains the outcome of the review
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Comment cannot be changed after creation"
+	Comment string `json:"comment,omitempty"`
+}
+
+type BreakGlassReviewStatus struct {
+}
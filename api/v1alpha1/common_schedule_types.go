@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Pending;Active;Denied;Expired
+type ScheduleState string
+
+const (
+	// ScheduleStatePending is the state of schedules waiting for approval
+	ScheduleStatePending ScheduleState = "Pending"
+	// ScheduleStateActive is the state of approved schedules, which create a
+	// grant for each occurrence
+	ScheduleStateActive ScheduleState = "Active"
+	ScheduleStateDenied ScheduleState = "Denied"
+	// ScheduleStateExpired is the state of schedules that weren't approved
+	// within the policy's pendingTimeout
+	ScheduleStateExpired ScheduleState = "Expired"
+)
+
+type AccessScheduleBaseSpec struct {
+	// Subject is the username or identity the schedule grants access to
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Subject cannot be changed after creation"
+	Subject string `json:"subject"`
+
+	// Groups are the groups the subject belongs to
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Groups cannot be changed after creation"
+	Groups []string `json:"groups,omitempty"`
+
+	// Role is an optional pre-defined Role/ClusterRole to bind
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Role cannot be changed after creation"
+	Role rbacv1.RoleRef `json:"role,omitempty"`
+
+	// Permissions are adhoc RBAC rules to grant (instead of a pre-defined role)
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Permissions cannot be changed after creation"
+	Permissions []rbacv1.PolicyRule `json:"permissions,omitempty"`
+
+	// GrantTarget is the Group or ServiceAccount the access is granted to instead
+	// of the subject, e.g. the group of an on-call rotation
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="GrantTarget cannot be changed after creation"
+	GrantTarget *rbacv1.Subject `json:"grantTarget,omitempty"`
+
+	// Schedule is a cron expression for the start of each occurrence
+	// (e.g. "0 9 * * MON"), evaluated in TimeZone
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Schedule cannot be changed after creation"
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in (e.g. "Europe/London")
+	// +optional
+	// +kubebuilder:default:="UTC"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TimeZone cannot be changed after creation"
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Duration of the access granted for each occurrence (e.g. "8h", "168h")
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Duration cannot be changed after creation"
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	Duration string `json:"duration"`
+
+	// User's justification for the schedule
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Justification cannot be changed after creation"
+	// +kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+
+	// JustificationFields are the structured justification fields required by the policy's justificationSchema
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="JustificationFields cannot be changed after creation"
+	JustificationFields map[string]string `json:"justificationFields,omitempty"`
+}
+
+type AccessScheduleStatus struct {
+	State ScheduleState `json:"state,omitempty"`
+
+	// Request is the name of the request created to approve the schedule
+	Request string `json:"request,omitempty"`
+
+	// ApprovedBy are the approvers of the schedule's request
+	ApprovedBy []string `json:"approvedBy,omitempty"`
+
+	// LastOccurrence is the start of the latest occurrence the schedule handled,
+	// including occurrences skipped because the policy no longer allowed them
+	LastOccurrence metav1.Time `json:"lastOccurrence,omitempty"`
+	// LastGrant is the name of the latest grant created by the schedule
+	LastGrant      string      `json:"lastGrant,omitempty"`
+	NextOccurrence metav1.Time `json:"nextOccurrence,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Active;Closed
+type IncidentModeState string
+
+const (
+	// IncidentModeStateActive is the state of incidents whose overrides apply to policies
+	IncidentModeStateActive IncidentModeState = "Active"
+	// IncidentModeStateClosed is the state of incidents that were closed or ran
+	// past their duration
+	IncidentModeStateClosed IncidentModeState = "Closed"
+)
+
+// IncidentOverrides relax the selected policies while the incident is active.
+// Overrides only ever widen access, policies that are already less strict are unchanged.
+// +kubebuilder:validation:XValidation:rule="has(self.requiredApprovals) || has(self.maxDuration) || has(self.autoApproveGroups)",message="at least one of requiredApprovals, maxDuration or autoApproveGroups must be set"
+type IncidentOverrides struct {
+	// RequiredApprovals lowers the number of approvals the policies require.
+	// Approval stages and quorum groups don't apply while it is set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequiredApprovals *int `json:"requiredApprovals,omitempty"`
+
+	// MaxDuration raises the longest duration that can be requested (e.g. "8h")
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// AutoApproveGroups auto-approves requests from members of these groups
+	// +optional
+	// +listType=set
+	AutoApproveGroups []string `json:"autoApproveGroups,omitempty"`
+}
+
+// IncidentModeSpec defines the policy overrides applied during an incident
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.closed) || !oldSelf.closed || (has(self.closed) && self.closed)",message="a closed incident can't be reopened"
+type IncidentModeSpec struct {
+	// Description of the incident, e.g. "Payments API outage"
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Closed ends the incident. A closed incident can't be reopened.
+	// +optional
+	Closed bool `json:"closed,omitempty"`
+
+	// Duration closes the incident automatically once it has been open this long (e.g. "6h")
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\d+(ns|us|µs|ms|s|m|h))+$`
+	Duration string `json:"duration,omitempty"`
+
+	// PolicySelector selects the AccessPolicies and ClusterAccessPolicies the
+	// overrides apply to by their labels. Every policy is selected when unset.
+	// +optional
+	PolicySelector *metav1.LabelSelector `json:"policySelector,omitempty"`
+
+	// Overrides are applied to the selected policies while the incident is active
+	// +required
+	Overrides IncidentOverrides `json:"overrides"`
+
+	// RevokeOnClose revokes the grants created under the incident when it closes
+	// +optional
+	RevokeOnClose bool `json:"revokeOnClose,omitempty"`
+}
+
+// IncidentModeStatus defines the observed state of IncidentMode.
+type IncidentModeStatus struct {
+	State IncidentModeState `json:"state,omitempty"`
+
+	// ExpiresAt is when an incident with a duration closes automatically
+	ExpiresAt metav1.Time `json:"expiresAt,omitempty"`
+
+	// ClosedAt is when the incident was closed
+	ClosedAt metav1.Time `json:"closedAt,omitempty"`
+
+	// RevokedGrants is the number of grants revoked when the incident closed
+	RevokedGrants int `json:"revokedGrants,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// IncidentMode is the Schema for the incidentmodes API
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Expires-At",type=string,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type IncidentMode struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of IncidentMode
+	// +required
+	Spec IncidentModeSpec `json:"spec"`
+
+	// status defines the observed state of IncidentMode
+	// +optional
+	Status IncidentModeStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// IncidentModeList contains a list of IncidentMode
+type IncidentModeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []IncidentMode `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IncidentMode{}, &IncidentModeList{})
+}
@@ -92,6 +92,16 @@ func (in *AccessGrantStatus) DeepCopyInto(out *AccessGrantStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GrantTarget != nil {
+		in, out := &in.GrantTarget, &out.GrantTarget
+		*out = new(v1.Subject)
+		**out = **in
+	}
+	if in.Delegation != nil {
+		in, out := &in.Delegation, &out.Delegation
+		*out = new(DelegationStatus)
+		**out = **in
+	}
 	out.Role = in.Role
 	if in.Permissions != nil {
 		in, out := &in.Permissions, &out.Permissions
@@ -100,7 +110,33 @@ func (in *AccessGrantStatus) DeepCopyInto(out *AccessGrantStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.JustificationFields != nil {
+		in, out := &in.JustificationFields, &out.JustificationFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(AccessWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]GrantExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.StartAt.DeepCopyInto(&out.StartAt)
 	in.AccessExpiresAt.DeepCopyInto(&out.AccessExpiresAt)
+	in.ExpiredAt.DeepCopyInto(&out.ExpiredAt)
+	if in.Revocation != nil {
+		in, out := &in.Revocation, &out.Revocation
+		*out = new(GrantRevocation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGrantStatus.
@@ -119,7 +155,7 @@ func (in *AccessPolicy) DeepCopyInto(out *AccessPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicy.
@@ -191,6 +227,13 @@ func (in *AccessPolicySpec) DeepCopy() *AccessPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessPolicyStatus) DeepCopyInto(out *AccessPolicyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyStatus.
@@ -203,6 +246,112 @@ func (in *AccessPolicyStatus) DeepCopy() *AccessPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyTemplate) DeepCopyInto(out *AccessPolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyTemplate.
+func (in *AccessPolicyTemplate) DeepCopy() *AccessPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyTemplateList) DeepCopyInto(out *AccessPolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyTemplateList.
+func (in *AccessPolicyTemplateList) DeepCopy() *AccessPolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyTemplateSpec) DeepCopyInto(out *AccessPolicyTemplateSpec) {
+	*out = *in
+	if in.AllowedRoles != nil {
+		in, out := &in.AllowedRoles, &out.AllowedRoles
+		*out = make([]v1.RoleRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedPermissions != nil {
+		in, out := &in.AllowedPermissions, &out.AllowedPermissions
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyTemplateSpec.
+func (in *AccessPolicyTemplateSpec) DeepCopy() *AccessPolicyTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyTemplateStatus) DeepCopyInto(out *AccessPolicyTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyTemplateStatus.
+func (in *AccessPolicyTemplateStatus) DeepCopy() *AccessPolicyTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessRequest) DeepCopyInto(out *AccessRequest) {
 	*out = *in
@@ -234,6 +383,11 @@ func (in *AccessRequest) DeepCopyObject() runtime.Object {
 func (in *AccessRequestApproval) DeepCopyInto(out *AccessRequestApproval) {
 	*out = *in
 	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+	if in.OnCall != nil {
+		in, out := &in.OnCall, &out.OnCall
+		*out = new(OnCallEntry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestApproval.
@@ -249,6 +403,11 @@ func (in *AccessRequestApproval) DeepCopy() *AccessRequestApproval {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessRequestBaseSpec) DeepCopyInto(out *AccessRequestBaseSpec) {
 	*out = *in
+	if in.DelegatorGroups != nil {
+		in, out := &in.DelegatorGroups, &out.DelegatorGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Groups != nil {
 		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
@@ -262,6 +421,27 @@ func (in *AccessRequestBaseSpec) DeepCopyInto(out *AccessRequestBaseSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GrantTarget != nil {
+		in, out := &in.GrantTarget, &out.GrantTarget
+		*out = new(v1.Subject)
+		**out = **in
+	}
+	if in.BreakGlass != nil {
+		in, out := &in.BreakGlass, &out.BreakGlass
+		*out = new(BreakGlassRequest)
+		**out = **in
+	}
+	if in.StartAt != nil {
+		in, out := &in.StartAt, &out.StartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.JustificationFields != nil {
+		in, out := &in.JustificationFields, &out.JustificationFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestBaseSpec.
@@ -333,6 +513,28 @@ func (in *AccessRequestStatus) DeepCopyInto(out *AccessRequestStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]ApprovalStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ticket != nil {
+		in, out := &in.Ticket, &out.Ticket
+		*out = new(TicketStatus)
+		**out = **in
+	}
+	if in.Delegation != nil {
+		in, out := &in.Delegation, &out.Delegation
+		*out = new(DelegationStatus)
+		**out = **in
+	}
+	if in.Review != nil {
+		in, out := &in.Review, &out.Review
+		*out = new(BreakGlassReviewRecord)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -447,25 +649,26 @@ func (in *AccessResponseStatus) DeepCopy() *AccessResponseStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessGrant) DeepCopyInto(out *ClusterAccessGrant) {
+func (in *AccessSchedule) DeepCopyInto(out *AccessSchedule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessGrant.
-func (in *ClusterAccessGrant) DeepCopy() *ClusterAccessGrant {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessSchedule.
+func (in *AccessSchedule) DeepCopy() *AccessSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessGrant)
+	out := new(AccessSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessGrant) DeepCopyObject() runtime.Object {
+func (in *AccessSchedule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -473,90 +676,71 @@ func (in *ClusterAccessGrant) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessGrantList) DeepCopyInto(out *ClusterAccessGrantList) {
+func (in *AccessScheduleBaseSpec) DeepCopyInto(out *AccessScheduleBaseSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterAccessGrant, len(*in))
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Role = in.Role
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]v1.PolicyRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessGrantList.
-func (in *ClusterAccessGrantList) DeepCopy() *ClusterAccessGrantList {
-	if in == nil {
-		return nil
+	if in.GrantTarget != nil {
+		in, out := &in.GrantTarget, &out.GrantTarget
+		*out = new(v1.Subject)
+		**out = **in
 	}
-	out := new(ClusterAccessGrantList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessGrantList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.JustificationFields != nil {
+		in, out := &in.JustificationFields, &out.JustificationFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessPolicy) DeepCopyInto(out *ClusterAccessPolicy) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicy.
-func (in *ClusterAccessPolicy) DeepCopy() *ClusterAccessPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessScheduleBaseSpec.
+func (in *AccessScheduleBaseSpec) DeepCopy() *AccessScheduleBaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessPolicy)
+	out := new(AccessScheduleBaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessPolicy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessPolicyList) DeepCopyInto(out *ClusterAccessPolicyList) {
+func (in *AccessScheduleList) DeepCopyInto(out *AccessScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterAccessPolicy, len(*in))
+		*out = make([]AccessSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicyList.
-func (in *ClusterAccessPolicyList) DeepCopy() *ClusterAccessPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessScheduleList.
+func (in *AccessScheduleList) DeepCopy() *AccessScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessPolicyList)
+	out := new(AccessScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessPolicyList) DeepCopyObject() runtime.Object {
+func (in *AccessScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -564,117 +748,825 @@ func (in *ClusterAccessPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessPolicySpec) DeepCopyInto(out *ClusterAccessPolicySpec) {
+func (in *AccessScheduleSpec) DeepCopyInto(out *AccessScheduleSpec) {
 	*out = *in
-	in.SubjectPolicy.DeepCopyInto(&out.SubjectPolicy)
+	in.AccessScheduleBaseSpec.DeepCopyInto(&out.AccessScheduleBaseSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicySpec.
-func (in *ClusterAccessPolicySpec) DeepCopy() *ClusterAccessPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessScheduleSpec.
+func (in *AccessScheduleSpec) DeepCopy() *AccessScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessPolicySpec)
+	out := new(AccessScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessRequest) DeepCopyInto(out *ClusterAccessRequest) {
+func (in *AccessScheduleStatus) DeepCopyInto(out *AccessScheduleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.ApprovedBy != nil {
+		in, out := &in.ApprovedBy, &out.ApprovedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastOccurrence.DeepCopyInto(&out.LastOccurrence)
+	in.NextOccurrence.DeepCopyInto(&out.NextOccurrence)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequest.
-func (in *ClusterAccessRequest) DeepCopy() *ClusterAccessRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessScheduleStatus.
+func (in *AccessScheduleStatus) DeepCopy() *AccessScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessRequest)
+	out := new(AccessScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessRequest) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessRequestList) DeepCopyInto(out *ClusterAccessRequestList) {
+func (in *AccessWindow) DeepCopyInto(out *AccessWindow) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterAccessRequest, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Weekdays != nil {
+		in, out := &in.Weekdays, &out.Weekdays
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hours != nil {
+		in, out := &in.Hours, &out.Hours
+		*out = make([]HourRange, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequestList.
-func (in *ClusterAccessRequestList) DeepCopy() *ClusterAccessRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessWindow.
+func (in *AccessWindow) DeepCopy() *AccessWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessRequestList)
+	out := new(AccessWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessRequestList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessRequestSpec) DeepCopyInto(out *ClusterAccessRequestSpec) {
+func (in *ApprovalStage) DeepCopyInto(out *ApprovalStage) {
 	*out = *in
-	in.AccessRequestBaseSpec.DeepCopyInto(&out.AccessRequestBaseSpec)
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequestSpec.
-func (in *ClusterAccessRequestSpec) DeepCopy() *ClusterAccessRequestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStage.
+func (in *ApprovalStage) DeepCopy() *ApprovalStage {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessRequestSpec)
+	out := new(ApprovalStage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessResponse) DeepCopyInto(out *ClusterAccessResponse) {
+func (in *ApprovalStageStatus) DeepCopyInto(out *ApprovalStageStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ApprovedBy != nil {
+		in, out := &in.ApprovedBy, &out.ApprovedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.CompletedAt.DeepCopyInto(&out.CompletedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStageStatus.
+func (in *ApprovalStageStatus) DeepCopy() *ApprovalStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoApprovalRule) DeepCopyInto(out *AutoApprovalRule) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PolicyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnCall != nil {
+		in, out := &in.OnCall, &out.OnCall
+		*out = new(OnCallRequirement)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoApprovalRule.
+func (in *AutoApprovalRule) DeepCopy() *AutoApprovalRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoApprovalRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassPolicy) DeepCopyInto(out *BreakGlassPolicy) {
+	*out = *in
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassPolicy.
+func (in *BreakGlassPolicy) DeepCopy() *BreakGlassPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassRequest) DeepCopyInto(out *BreakGlassRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassRequest.
+func (in *BreakGlassRequest) DeepCopy() *BreakGlassRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassReview) DeepCopyInto(out *BreakGlassReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessResponse.
-func (in *ClusterAccessResponse) DeepCopy() *ClusterAccessResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassReview.
+func (in *BreakGlassReview) DeepCopy() *BreakGlassReview {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakGlassReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassReviewList) DeepCopyInto(out *BreakGlassReviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BreakGlassReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassReviewList.
+func (in *BreakGlassReviewList) DeepCopy() *BreakGlassReviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassReviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakGlassReviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassReviewRecord) DeepCopyInto(out *BreakGlassReviewRecord) {
+	*out = *in
+	in.ReviewedAt.DeepCopyInto(&out.ReviewedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassReviewRecord.
+func (in *BreakGlassReviewRecord) DeepCopy() *BreakGlassReviewRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassReviewRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassReviewSpec) DeepCopyInto(out *BreakGlassReviewSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassReviewSpec.
+func (in *BreakGlassReviewSpec) DeepCopy() *BreakGlassReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassReviewStatus) DeepCopyInto(out *BreakGlassReviewStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassReviewStatus.
+func (in *BreakGlassReviewStatus) DeepCopy() *BreakGlassReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessGrant) DeepCopyInto(out *ClusterAccessGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessGrant.
+func (in *ClusterAccessGrant) DeepCopy() *ClusterAccessGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessGrantList) DeepCopyInto(out *ClusterAccessGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAccessGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessGrantList.
+func (in *ClusterAccessGrantList) DeepCopy() *ClusterAccessGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessPolicy) DeepCopyInto(out *ClusterAccessPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicy.
+func (in *ClusterAccessPolicy) DeepCopy() *ClusterAccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessPolicyList) DeepCopyInto(out *ClusterAccessPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAccessPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicyList.
+func (in *ClusterAccessPolicyList) DeepCopy() *ClusterAccessPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessPolicySpec) DeepCopyInto(out *ClusterAccessPolicySpec) {
+	*out = *in
+	in.SubjectPolicy.DeepCopyInto(&out.SubjectPolicy)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessPolicySpec.
+func (in *ClusterAccessPolicySpec) DeepCopy() *ClusterAccessPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessRequest) DeepCopyInto(out *ClusterAccessRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequest.
+func (in *ClusterAccessRequest) DeepCopy() *ClusterAccessRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessRequestList) DeepCopyInto(out *ClusterAccessRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAccessRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequestList.
+func (in *ClusterAccessRequestList) DeepCopy() *ClusterAccessRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessRequestSpec) DeepCopyInto(out *ClusterAccessRequestSpec) {
+	*out = *in
+	in.AccessRequestBaseSpec.DeepCopyInto(&out.AccessRequestBaseSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessRequestSpec.
+func (in *ClusterAccessRequestSpec) DeepCopy() *ClusterAccessRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessResponse) DeepCopyInto(out *ClusterAccessResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessResponse.
+func (in *ClusterAccessResponse) DeepCopy() *ClusterAccessResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessResponseList) DeepCopyInto(out *ClusterAccessResponseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAccessResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessResponseList.
+func (in *ClusterAccessResponseList) DeepCopy() *ClusterAccessResponseList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessResponseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessResponseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessSchedule) DeepCopyInto(out *ClusterAccessSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessSchedule.
+func (in *ClusterAccessSchedule) DeepCopy() *ClusterAccessSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessScheduleList) DeepCopyInto(out *ClusterAccessScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAccessSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessScheduleList.
+func (in *ClusterAccessScheduleList) DeepCopy() *ClusterAccessScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAccessScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessScheduleSpec) DeepCopyInto(out *ClusterAccessScheduleSpec) {
+	*out = *in
+	in.AccessScheduleBaseSpec.DeepCopyInto(&out.AccessScheduleBaseSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessScheduleSpec.
+func (in *ClusterAccessScheduleSpec) DeepCopy() *ClusterAccessScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAccessScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBreakGlassReview) DeepCopyInto(out *ClusterBreakGlassReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBreakGlassReview.
+func (in *ClusterBreakGlassReview) DeepCopy() *ClusterBreakGlassReview {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBreakGlassReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBreakGlassReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBreakGlassReviewList) DeepCopyInto(out *ClusterBreakGlassReviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBreakGlassReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBreakGlassReviewList.
+func (in *ClusterBreakGlassReviewList) DeepCopy() *ClusterBreakGlassReviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBreakGlassReviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBreakGlassReviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegationStatus) DeepCopyInto(out *DelegationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegationStatus.
+func (in *DelegationStatus) DeepCopy() *DelegationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessResponse)
+	out := new(DelegationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantExtension) DeepCopyInto(out *GrantExtension) {
+	*out = *in
+	if in.ApprovedBy != nil {
+		in, out := &in.ApprovedBy, &out.ApprovedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ExtendedAt.DeepCopyInto(&out.ExtendedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantExtension.
+func (in *GrantExtension) DeepCopy() *GrantExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantExtensions) DeepCopyInto(out *GrantExtensions) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantExtensions.
+func (in *GrantExtensions) DeepCopy() *GrantExtensions {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantExtensions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantRevocation) DeepCopyInto(out *GrantRevocation) {
+	*out = *in
+	in.RevokedAt.DeepCopyInto(&out.RevokedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantRevocation.
+func (in *GrantRevocation) DeepCopy() *GrantRevocation {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantRevocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupQuorum) DeepCopyInto(out *GroupQuorum) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupQuorum.
+func (in *GroupQuorum) DeepCopy() *GroupQuorum {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupQuorum)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HourRange) DeepCopyInto(out *HourRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HourRange.
+func (in *HourRange) DeepCopy() *HourRange {
+	if in == nil {
+		return nil
+	}
+	out := new(HourRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentMode) DeepCopyInto(out *IncidentMode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentMode.
+func (in *IncidentMode) DeepCopy() *IncidentMode {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentMode)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessResponse) DeepCopyObject() runtime.Object {
+func (in *IncidentMode) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -682,37 +1574,162 @@ func (in *ClusterAccessResponse) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterAccessResponseList) DeepCopyInto(out *ClusterAccessResponseList) {
+func (in *IncidentModeList) DeepCopyInto(out *IncidentModeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterAccessResponse, len(*in))
+		*out = make([]IncidentMode, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessResponseList.
-func (in *ClusterAccessResponseList) DeepCopy() *ClusterAccessResponseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentModeList.
+func (in *IncidentModeList) DeepCopy() *IncidentModeList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterAccessResponseList)
+	out := new(IncidentModeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterAccessResponseList) DeepCopyObject() runtime.Object {
+func (in *IncidentModeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentModeSpec) DeepCopyInto(out *IncidentModeSpec) {
+	*out = *in
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Overrides.DeepCopyInto(&out.Overrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentModeSpec.
+func (in *IncidentModeSpec) DeepCopy() *IncidentModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentModeStatus) DeepCopyInto(out *IncidentModeStatus) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	in.ClosedAt.DeepCopyInto(&out.ClosedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentModeStatus.
+func (in *IncidentModeStatus) DeepCopy() *IncidentModeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentModeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentOverrides) DeepCopyInto(out *IncidentOverrides) {
+	*out = *in
+	if in.RequiredApprovals != nil {
+		in, out := &in.RequiredApprovals, &out.RequiredApprovals
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutoApproveGroups != nil {
+		in, out := &in.AutoApproveGroups, &out.AutoApproveGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentOverrides.
+func (in *IncidentOverrides) DeepCopy() *IncidentOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JustificationField) DeepCopyInto(out *JustificationField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JustificationField.
+func (in *JustificationField) DeepCopy() *JustificationField {
+	if in == nil {
+		return nil
+	}
+	out := new(JustificationField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnCallEntry) DeepCopyInto(out *OnCallEntry) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnCallEntry.
+func (in *OnCallEntry) DeepCopy() *OnCallEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(OnCallEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnCallRequirement) DeepCopyInto(out *OnCallRequirement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnCallRequirement.
+func (in *OnCallRequirement) DeepCopy() *OnCallRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(OnCallRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyCondition) DeepCopyInto(out *PolicyCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyCondition.
+func (in *PolicyCondition) DeepCopy() *PolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubjectPolicy) DeepCopyInto(out *SubjectPolicy) {
 	*out = *in
@@ -721,6 +1738,11 @@ func (in *SubjectPolicy) DeepCopyInto(out *SubjectPolicy) {
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowDelegation != nil {
+		in, out := &in.AllowDelegation, &out.AllowDelegation
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
 	if in.AllowedRoles != nil {
 		in, out := &in.AllowedRoles, &out.AllowedRoles
 		*out = make([]v1.RoleRef, len(*in))
@@ -733,11 +1755,82 @@ func (in *SubjectPolicy) DeepCopyInto(out *SubjectPolicy) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowedGrantTargets != nil {
+		in, out := &in.AllowedGrantTargets, &out.AllowedGrantTargets
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedRoles != nil {
+		in, out := &in.DeniedRoles, &out.DeniedRoles
+		*out = make([]v1.RoleRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedPermissions != nil {
+		in, out := &in.DeniedPermissions, &out.DeniedPermissions
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Approvers != nil {
 		in, out := &in.Approvers, &out.Approvers
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApprovalStages != nil {
+		in, out := &in.ApprovalStages, &out.ApprovalStages
+		*out = make([]ApprovalStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApprovalQuorum != nil {
+		in, out := &in.ApprovalQuorum, &out.ApprovalQuorum
+		*out = make([]GroupQuorum, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovals != nil {
+		in, out := &in.AutoApprovals, &out.AutoApprovals
+		*out = make([]AutoApprovalRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(AccessWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(SubjectQuota)
+		**out = **in
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = new(GrantExtensions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BreakGlass != nil {
+		in, out := &in.BreakGlass, &out.BreakGlass
+		*out = new(BreakGlassPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PolicyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.JustificationSchema != nil {
+		in, out := &in.JustificationSchema, &out.JustificationSchema
+		*out = make([]JustificationField, len(*in))
+		copy(*out, *in)
+	}
+	if in.TicketValidation != nil {
+		in, out := &in.TicketValidation, &out.TicketValidation
+		*out = new(TicketValidation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPolicy.
@@ -749,3 +1842,48 @@ func (in *SubjectPolicy) DeepCopy() *SubjectPolicy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectQuota) DeepCopyInto(out *SubjectQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectQuota.
+func (in *SubjectQuota) DeepCopy() *SubjectQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TicketStatus) DeepCopyInto(out *TicketStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TicketStatus.
+func (in *TicketStatus) DeepCopy() *TicketStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TicketStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TicketValidation) DeepCopyInto(out *TicketValidation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TicketValidation.
+func (in *TicketValidation) DeepCopy() *TicketValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(TicketValidation)
+	in.DeepCopyInto(out)
+	return out
+}
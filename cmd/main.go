@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -41,7 +42,9 @@ import (
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/controller"
 	"github.com/itsthatdude/jit-access-controller/internal/metrics"
+	"github.com/itsthatdude/jit-access-controller/internal/oncall"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
 	webhookv1alpha1 "github.com/itsthatdude/jit-access-controller/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -69,6 +72,10 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var ticketValidationURL string
+	var ticketValidationTimeout time.Duration
+	var onCallURL string
+	var onCallTimeout time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -87,6 +94,14 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&ticketValidationURL, "ticket-validation-url", "",
+		"The endpoint used to validate tickets for policies with ticketValidation.")
+	flag.DurationVar(&ticketValidationTimeout, "ticket-validation-timeout", 10*time.Second,
+		"The timeout for calls to the ticket validation endpoint.")
+	flag.StringVar(&onCallURL, "on-call-url", "",
+		"The endpoint used to look up HTTP on-call rosters for auto-approval rules.")
+	flag.DurationVar(&onCallTimeout, "on-call-timeout", 10*time.Second,
+		"The timeout for calls to the on-call endpoint.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -209,6 +224,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	ticketValidator := ticket.NewValidator(ticketValidationURL, ticketValidationTimeout)
+	onCall := &oncall.Resolver{
+		Client:    mgr.GetAPIReader(),
+		Namespace: namespace,
+		Endpoint:  oncall.NewEndpoint(onCallURL, onCallTimeout),
+	}
+
 	clusterPolicyManager := policy.NewPolicyManager()
 	namespacedPolicyManager := policy.NewPolicyManager()
 
@@ -226,6 +248,14 @@ func main() {
 
 	ctx := context.Background()
 
+	if err := policy.LoadPolicyTemplates(ctx, cli, clusterPolicyManager, namespacedPolicyManager); err != nil {
+		setupLog.Error(err, "failed to load existing policy templates")
+		os.Exit(1)
+	}
+	if err := policy.LoadIncidentModes(ctx, cli, clusterPolicyManager, namespacedPolicyManager); err != nil {
+		setupLog.Error(err, "failed to load existing incident modes")
+		os.Exit(1)
+	}
 	if err := policy.LoadClusterPolicies(ctx, cli, clusterPolicyManager); err != nil {
 		setupLog.Error(err, "failed to load existing cluster policies")
 		os.Exit(1)
@@ -235,6 +265,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := (&controller.AccessPolicyTemplateReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		PolicyManagers: []*policy.PolicyManager{clusterPolicyManager, namespacedPolicyManager},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "AccessPolicyTemplate")
+		os.Exit(1)
+	}
+
+	if err := (&controller.IncidentModeReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorder("incidentmode-controller"),
+		PolicyManagers: []*policy.PolicyManager{clusterPolicyManager, namespacedPolicyManager},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "IncidentMode")
+		os.Exit(1)
+	}
+
 	if err := (&controller.ClusterAccessPolicyReconciler{
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
@@ -254,20 +303,26 @@ func main() {
 	}
 
 	if err := (&controller.ClusterAccessRequestReconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		PolicyManager:  clusterPolicyManager,
-		PolicyResolver: &policy.PolicyResolver{},
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorder("accessrequest-controller"),
+		PolicyManager:   clusterPolicyManager,
+		PolicyResolver:  &policy.PolicyResolver{Client: mgr.GetClient()},
+		TicketValidator: ticketValidator,
+		OnCall:          onCall,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "ClusterAccessRequest")
 		os.Exit(1)
 	}
 
 	if err := (&controller.AccessRequestReconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		PolicyManager:  namespacedPolicyManager,
-		PolicyResolver: &policy.PolicyResolver{},
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorder("accessrequest-controller"),
+		PolicyManager:   namespacedPolicyManager,
+		PolicyResolver:  &policy.PolicyResolver{ClusterPolicies: clusterPolicyManager, Client: mgr.GetClient()},
+		TicketValidator: ticketValidator,
+		OnCall:          onCall,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "AccessRequest")
 		os.Exit(1)
@@ -291,21 +346,60 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := (&controller.ClusterAccessScheduleReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorder("accessschedule-controller"),
+		PolicyManager:  clusterPolicyManager,
+		PolicyResolver: &policy.PolicyResolver{Client: mgr.GetClient()},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "ClusterAccessSchedule")
+		os.Exit(1)
+	}
+
+	if err := (&controller.AccessScheduleReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorder("accessschedule-controller"),
+		PolicyManager:  namespacedPolicyManager,
+		PolicyResolver: &policy.PolicyResolver{ClusterPolicies: clusterPolicyManager, Client: mgr.GetClient()},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "AccessSchedule")
+		os.Exit(1)
+	}
+
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		webhookv1alpha1.SetupClusterAccessRequestMutatingWebhookWithManager(mgr)
+		webhookv1alpha1.SetupClusterAccessPolicyWebhookWithManager(mgr, clusterPolicyManager)
+		webhookv1alpha1.SetupAccessPolicyWebhookWithManager(mgr, namespacedPolicyManager)
+
+		webhookv1alpha1.SetupClusterAccessRequestMutatingWebhookWithManager(mgr, clusterPolicyManager)
 		webhookv1alpha1.SetupClusterAccessResponseMutatingWebhookWithManager(mgr, namespace, frontendServiceAccount)
-		webhookv1alpha1.SetupClusterAccessRequestWebhookWithManager(mgr, namespace, serviceAccount, clusterPolicyManager)
+		webhookv1alpha1.SetupClusterAccessRequestWebhookWithManager(
+			mgr, namespace, serviceAccount, clusterPolicyManager, ticketValidator,
+		)
 		webhookv1alpha1.SetupClusterAccessResponseWebhookWithManager(
 			mgr, namespace, serviceAccount, frontendServiceAccount, clusterPolicyManager,
 		)
+		webhookv1alpha1.SetupClusterBreakGlassReviewMutatingWebhookWithManager(mgr, namespace, frontendServiceAccount)
+		webhookv1alpha1.SetupClusterBreakGlassReviewWebhookWithManager(mgr, namespace, frontendServiceAccount, clusterPolicyManager)
+		webhookv1alpha1.SetupClusterAccessScheduleMutatingWebhookWithManager(mgr)
+		webhookv1alpha1.SetupClusterAccessScheduleWebhookWithManager(mgr, clusterPolicyManager, ticketValidator)
 
-		webhookv1alpha1.SetupAccessRequestMutatingWebhookWithManager(mgr)
+		webhookv1alpha1.SetupAccessRequestMutatingWebhookWithManager(mgr, namespacedPolicyManager, clusterPolicyManager)
 		webhookv1alpha1.SetupAccessResponseMutatingWebhookWithManager(mgr, namespace, frontendServiceAccount)
-		webhookv1alpha1.SetupAccessRequestWebhookWithManager(mgr, namespace, serviceAccount, namespacedPolicyManager)
+		webhookv1alpha1.SetupAccessRequestWebhookWithManager(
+			mgr, namespace, serviceAccount, namespacedPolicyManager, clusterPolicyManager, ticketValidator,
+		)
 		webhookv1alpha1.SetupAccessResponseWebhookWithManager(
-			mgr, namespace, serviceAccount, frontendServiceAccount, namespacedPolicyManager,
+			mgr, namespace, serviceAccount, frontendServiceAccount, namespacedPolicyManager, clusterPolicyManager,
+		)
+		webhookv1alpha1.SetupBreakGlassReviewMutatingWebhookWithManager(mgr, namespace, frontendServiceAccount)
+		webhookv1alpha1.SetupBreakGlassReviewWebhookWithManager(
+			mgr, namespace, frontendServiceAccount, namespacedPolicyManager, clusterPolicyManager,
 		)
+		webhookv1alpha1.SetupAccessScheduleMutatingWebhookWithManager(mgr)
+		webhookv1alpha1.SetupAccessScheduleWebhookWithManager(mgr, namespacedPolicyManager, clusterPolicyManager, ticketValidator)
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -2,6 +2,7 @@ package common
 
 import (
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,15 +27,40 @@ type AccessRequestObject interface {
 	GetSubject() string
 }
 
+type AccessScheduleObject interface {
+	client.Object
+	GetSpec() *v1alpha1.AccessScheduleBaseSpec
+	GetStatus() *v1alpha1.AccessScheduleStatus
+	SetStatus(status *v1alpha1.AccessScheduleStatus)
+	GetScope() v1alpha1.RequestScope
+}
+
 type AccessResponseObject interface {
 	client.Object
 	GetResponse() v1alpha1.ResponseState
 	GetApprover() string
+	GetReason() string
+}
+
+type BreakGlassReviewObject interface {
+	client.Object
+	GetReviewer() string
+	GetOutcome() v1alpha1.ReviewOutcome
+	GetComment() string
 }
 
 type AccessPolicyObject interface {
 	GetName() string
 	GetNamespace() string
+	GetLabels() map[string]string
 	GetScope() v1alpha1.PolicyScope
 	GetPolicy() v1alpha1.SubjectPolicy
+	GetNamespaceSelector() *metav1.LabelSelector
+}
+
+type AccessPolicyStatusObject interface {
+	client.Object
+	AccessPolicyObject
+	GetStatus() *v1alpha1.AccessPolicyStatus
+	SetStatus(status *v1alpha1.AccessPolicyStatus)
 }
@@ -24,8 +24,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
@@ -126,5 +128,89 @@ var _ = Describe("AccessGrant Controller", func() {
 			// Reconcile to run the cleanup logic
 			reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(grantObj)).Should(Succeed())
 		})
+
+		// revokeGrant activates a grant for user1, has the approver revoke it
+		// and returns the grant once the revocation was handled
+		revokeGrant := func(approver string) *v1alpha1.AccessGrant {
+			grantName := fmt.Sprintf("test-grant-%d", time.Now().UnixNano())
+
+			grantObj := &v1alpha1.AccessGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      grantName,
+					Namespace: "default",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, grantObj)).To(Succeed())
+			waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(grantObj), grantObj)
+
+			grantObj.Status.ApprovedBy = []string{"admin"}
+			grantObj.Status.Request = grantName
+			grantObj.Status.RequestId = grantName
+			grantObj.Status.Role = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: "edit"}
+			grantObj.Status.Subject = "user1"
+			grantObj.Status.Duration = "10m"
+			// Retain the grant once it is revoked so the revocation can be checked
+			grantObj.Status.RetainAfterExpiry = "1h"
+
+			Expect(k8sClient.Status().Update(ctx, grantObj)).To(Succeed())
+
+			reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(grantObj)).Should(Succeed())
+
+			roleBindingKey := client.ObjectKey{Name: fmt.Sprintf("jit-access-%s", grantName), Namespace: grantObj.Namespace}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, roleBindingKey, &rbacv1.RoleBinding{})
+			}, 5*time.Second, 500*time.Millisecond).Should(Succeed())
+
+			response := &v1alpha1.AccessResponse{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      grantName + "-revoked",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.AccessResponseSpec{
+					RequestRef: grantName,
+					Approver:   approver,
+					Response:   v1alpha1.ResponseStateRevoked,
+					Reason:     "access is no longer needed",
+				},
+			}
+			Expect(k8sClient.Create(ctx, response)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, response)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, grantObj))).To(Succeed())
+				reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(grantObj)).Should(Succeed())
+			})
+
+			Eventually(func(g Gomega) {
+				_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(grantObj)})
+				g.Expect(err).NotTo(HaveOccurred())
+
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(grantObj), grantObj)).To(Succeed())
+				g.Expect(grantObj.Status.Revocation).NotTo(BeNil())
+				g.Expect(grantObj.Status.ExpiredAt.IsZero()).To(BeFalse())
+			}, 10*time.Second, 500*time.Millisecond).Should(Succeed())
+
+			// The grant's access is removed straight away
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, roleBindingKey, &rbacv1.RoleBinding{}))
+			}, 5*time.Second, 500*time.Millisecond).Should(BeTrue())
+
+			return grantObj
+		}
+
+		It("should revoke an active AccessGrant when its request receives a Revoked response", func() {
+			grantObj := revokeGrant("admin")
+
+			Expect(grantObj.Status.Revocation.RevokedBy).To(Equal("admin"))
+			Expect(grantObj.Status.Revocation.Reason).To(Equal("access is no longer needed"))
+			Expect(grantObj.Status.Revocation.Released).To(BeFalse())
+		})
+
+		It("should mark an AccessGrant revoked by its subject as released", func() {
+			grantObj := revokeGrant("user1")
+
+			Expect(grantObj.Status.Revocation.RevokedBy).To(Equal("user1"))
+			Expect(grantObj.Status.Revocation.Released).To(BeTrue())
+		})
 	})
 })
@@ -22,10 +22,16 @@ import (
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/common"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // AccessPolicyReconciler reconciles a AccessPolicy object
@@ -33,12 +39,17 @@ type AccessPolicyReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	PolicyManager *policy.PolicyManager
+	Processor     *processors.PolicyProcessor
 }
 
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicies/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessrequests;clusteraccessrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessgrants;clusteraccessgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=get;list;watch
+
 func (r *AccessPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = logf.FromContext(ctx)
 
@@ -48,19 +59,68 @@ func (r *AccessPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	objs := make([]common.AccessPolicyObject, 0, len(list.Items))
+	statusObjs := make([]common.AccessPolicyStatusObject, 0, len(list.Items))
 	for i := range list.Items {
 		objs = append(objs, &list.Items[i])
+		statusObjs = append(statusObjs, &list.Items[i])
 	}
 
 	r.PolicyManager.Update(objs)
 
+	if err := r.Processor.ReconcilePolicyStatus(ctx, statusObjs); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccessPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Processor = &processors.PolicyProcessor{
+		Client:        r.Client,
+		PolicyManager: r.PolicyManager,
+	}
+
+	// Requests and grants resolved to a policy change its usage counts
+	policyKey := func(namespace, name string, scope v1alpha1.PolicyScope) []reconcile.Request {
+		if name == "" || scope == "Cluster" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.AccessPolicy{}).
+		For(&v1alpha1.AccessPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&v1alpha1.AccessRequest{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				req := obj.(*v1alpha1.AccessRequest)
+				return policyKey(req.Namespace, req.Status.ResolvedPolicy, req.Status.ResolvedPolicyScope)
+			}),
+		).
+		Watches(
+			&v1alpha1.AccessGrant{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				grant := obj.(*v1alpha1.AccessGrant)
+				return policyKey(grant.Namespace, grant.Status.Policy, grant.Status.PolicyScope)
+			}),
+		).
+		Watches(
+			&v1alpha1.AccessPolicyTemplate{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				var list v1alpha1.AccessPolicyList
+				if err := r.List(ctx, &list); err != nil {
+					return nil
+				}
+				var requests []reconcile.Request
+				for _, p := range list.Items {
+					if p.Spec.Template == obj.GetName() {
+						requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Name}})
+					}
+				}
+				return requests
+			}),
+		).
 		Named("accesspolicy").
 		Complete(r)
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AccessPolicyTemplateReconciler reconciles a AccessPolicyTemplate object
+type AccessPolicyTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// PolicyManagers hold the policies that can reference templates
+	PolicyManagers []*policy.PolicyManager
+}
+
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicytemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicytemplates/status,verbs=get;update;patch
+
+func (r *AccessPolicyTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = logf.FromContext(ctx)
+
+	var list v1alpha1.AccessPolicyTemplateList
+	if err := r.List(ctx, &list); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, manager := range r.PolicyManagers {
+		manager.UpdateTemplates(list.Items)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccessPolicyTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.AccessPolicyTemplate{}).
+		Named("accesspolicytemplate").
+		Complete(r)
+}
@@ -23,6 +23,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,17 +34,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/oncall"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
 	"github.com/itsthatdude/jit-access-controller/internal/processors"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
 )
 
 // AccessRequestReconciler reconciles a AccessRequest object
 type AccessRequestReconciler struct {
 	client.Client
-	Scheme         *runtime.Scheme
-	PolicyManager  *policy.PolicyManager
-	PolicyResolver *policy.PolicyResolver
-	Processor      *processors.RequestProcessor
+	Scheme          *runtime.Scheme
+	Recorder        events.EventRecorder
+	PolicyManager   *policy.PolicyManager
+	PolicyResolver  *policy.PolicyResolver
+	TicketValidator *ticket.Validator
+	OnCall          *oncall.Resolver
+	Processor       *processors.RequestProcessor
 }
 
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accesspolicies,verbs=get;list;watch;create;update;patch;delete
@@ -58,9 +64,14 @@ type AccessRequestReconciler struct {
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accessresponses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=accessresponses/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=breakglassreviews,verbs=get;list;watch;create;update;patch;delete
+
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;delete;bind;escalate
 
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,namespace=system,resources=configmaps,verbs=get
+
 func (r *AccessRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = logf.FromContext(ctx)
 
@@ -79,10 +90,13 @@ func (r *AccessRequestReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Processor = &processors.RequestProcessor{
-		Client:         r.Client,
-		Scheme:         r.Scheme,
-		PolicyManager:  r.PolicyManager,
-		PolicyResolver: r.PolicyResolver,
+		Client:          r.Client,
+		Scheme:          r.Scheme,
+		Recorder:        r.Recorder,
+		PolicyManager:   r.PolicyManager,
+		PolicyResolver:  r.PolicyResolver,
+		TicketValidator: r.TicketValidator,
+		OnCall:          r.OnCall,
 	}
 
 	ctx := context.Background()
@@ -114,6 +128,19 @@ func (r *AccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to add index for requestRef: %w", err)
 	}
 
+	if err := indexer.IndexField(ctx, &v1alpha1.BreakGlassReview{}, "spec.requestRef",
+		func(obj client.Object) []string {
+			if myObj, ok := obj.(*v1alpha1.BreakGlassReview); ok {
+				if myObj.Spec.RequestRef == "" {
+					return nil
+				}
+				return []string{myObj.Spec.RequestRef}
+			}
+			return nil
+		}); err != nil {
+		return fmt.Errorf("failed to add index for review requestRef: %w", err)
+	}
+
 	eventFilter := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
 			return true
@@ -141,6 +168,19 @@ func (r *AccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(eventFilter),
 		).
+		Watches(
+			&v1alpha1.BreakGlassReview{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				review := obj.(*v1alpha1.BreakGlassReview)
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Namespace: review.Namespace,
+						Name:      review.Spec.RequestRef,
+					},
+				}}
+			}),
+			builder.WithPredicates(eventFilter),
+		).
 		Named("request-controller").
 		Complete(r)
 }
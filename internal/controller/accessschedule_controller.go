@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
+)
+
+// AccessScheduleReconciler reconciles a AccessSchedule object
+type AccessScheduleReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Recorder       events.EventRecorder
+	PolicyManager  *policy.PolicyManager
+	PolicyResolver *policy.PolicyResolver
+	Processor      *processors.ScheduleProcessor
+}
+
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessschedules/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessgrants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessgrants/status,verbs=get;update;patch
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *AccessScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = logf.FromContext(ctx)
+
+	var obj accessv1alpha1.AccessSchedule
+	err := r.Get(ctx, req.NamespacedName, &obj)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	return r.Processor.ReconcileSchedule(ctx, &obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccessScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Processor = &processors.ScheduleProcessor{
+		Client:         r.Client,
+		Scheme:         r.Scheme,
+		Recorder:       r.Recorder,
+		PolicyManager:  r.PolicyManager,
+		PolicyResolver: r.PolicyResolver,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&accessv1alpha1.AccessSchedule{}).
+		// The schedule is activated when its request is approved
+		Owns(&accessv1alpha1.AccessRequest{}).
+		Named("schedule-controller").
+		Complete(r)
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("AccessSchedule Controller", func() {
+	var (
+		ctx        context.Context
+		reconciler *AccessScheduleReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		reconciler = &AccessScheduleReconciler{
+			Client:         mgr.GetClient(),
+			Scheme:         scheme.Scheme,
+			Recorder:       mgr.GetEventRecorder("accessschedule-controller"),
+			PolicyManager:  policy.NewPolicyManager(),
+			PolicyResolver: &policy.PolicyResolver{},
+		}
+
+		reconciler.Processor = &processors.ScheduleProcessor{
+			Client:         reconciler.Client,
+			Scheme:         reconciler.Scheme,
+			Recorder:       reconciler.Recorder,
+			PolicyManager:  reconciler.PolicyManager,
+			PolicyResolver: reconciler.PolicyResolver,
+		}
+	})
+
+	It("should create a request to approve the AccessSchedule", func() {
+		scheduleName := fmt.Sprintf("test-schedule-%d", time.Now().UnixNano())
+
+		scheduleObj := &v1alpha1.AccessSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      scheduleName,
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AccessScheduleSpec{
+				AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+					Subject:       "user1",
+					Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "edit"},
+					Schedule:      "0 9 * * MON",
+					Duration:      "8h",
+					Justification: "Weekly release",
+				},
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, scheduleObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(scheduleObj)).Should(Succeed())
+
+		requestObj := &v1alpha1.AccessRequest{}
+		waitForCreated(ctx, k8sClient, client.ObjectKey{Name: scheduleName, Namespace: "default"}, requestObj)
+		Expect(requestObj.Spec.AccessSchedule).To(Equal(scheduleName))
+		Expect(requestObj.Spec.Subject).To(Equal("user1"))
+
+		Eventually(func() (v1alpha1.ScheduleState, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+			return scheduleObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.ScheduleStatePending))
+
+		Expect(k8sClient.Delete(ctx, scheduleObj)).To(Succeed())
+	})
+
+	It("should not take over an existing AccessRequest with the AccessSchedule's name", func() {
+		name := fmt.Sprintf("test-schedule-%d", time.Now().UnixNano())
+
+		requestObj := &v1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: v1alpha1.AccessRequestBaseSpec{
+					Subject:       "user1",
+					Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "view"},
+					Duration:      "1h",
+					Justification: "Debugging",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, requestObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(requestObj), requestObj)
+
+		requestObj.Status.State = v1alpha1.RequestStateApproved
+		Expect(k8sClient.Status().Update(ctx, requestObj)).To(Succeed())
+
+		scheduleObj := &v1alpha1.AccessSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AccessScheduleSpec{
+				AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+					Subject:       "user1",
+					Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "admin"},
+					Schedule:      "0 9 * * MON",
+					Duration:      "8h",
+					Justification: "Weekly release",
+				},
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, scheduleObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(scheduleObj)).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)).To(Succeed())
+			g.Expect(scheduleObj.Status.State).To(Equal(v1alpha1.ScheduleStatePending))
+			g.Expect(scheduleObj.Status.Request).To(BeEmpty())
+
+			approved := meta.FindStatusCondition(scheduleObj.Status.Conditions, "Approved")
+			g.Expect(approved).NotTo(BeNil())
+			g.Expect(approved.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(approved.Reason).To(Equal("RequestConflict"))
+		}, 5*time.Second, 500*time.Millisecond).Should(Succeed())
+
+		// Reconciling again doesn't approve the schedule with the request
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(scheduleObj)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)).To(Succeed())
+		Expect(scheduleObj.Status.State).To(Equal(v1alpha1.ScheduleStatePending))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(requestObj), requestObj)).To(Succeed())
+		Expect(requestObj.OwnerReferences).To(BeEmpty())
+		Expect(requestObj.Spec.Role.Name).To(Equal("view"))
+
+		Expect(k8sClient.Delete(ctx, scheduleObj)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, requestObj)).To(Succeed())
+	})
+
+	It("should revoke the grants of occurrences granted under an IncidentMode when it closes", func() {
+		name := fmt.Sprintf("test-schedule-%d", time.Now().UnixNano())
+
+		// The incident allows the schedule's duration, which the policy doesn't
+		incidentObj := &v1alpha1.IncidentMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1alpha1.IncidentModeSpec{
+				Description:   "Payments API outage",
+				Overrides:     v1alpha1.IncidentOverrides{MaxDuration: "8h"},
+				RevokeOnClose: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, incidentObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(incidentObj), incidentObj)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, incidentObj))).To(Succeed())
+		})
+
+		reconciler.PolicyManager.Update([]common.AccessPolicyObject{&v1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AccessPolicySpec{
+				SubjectPolicy: v1alpha1.SubjectPolicy{
+					Requesters:        []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "user1"}},
+					RequiredApprovals: 1,
+					AllowedRoles:      []rbacv1.RoleRef{{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "edit"}},
+					Approvers:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "admin"}},
+					MaxDuration:       "1h",
+				},
+			},
+		}})
+		reconciler.PolicyManager.UpdateIncidents([]v1alpha1.IncidentMode{*incidentObj}, time.Now())
+
+		scheduleObj := &v1alpha1.AccessSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AccessScheduleSpec{
+				AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+					Subject:       "user1",
+					Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "edit"},
+					Schedule:      "* * * * *",
+					Duration:      "8h",
+					Justification: "Incident response",
+				},
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, scheduleObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, scheduleObj))).To(Succeed())
+		})
+
+		// Approve the schedule, and wait for the cache to see it approved
+		scheduleObj.Status.State = v1alpha1.ScheduleStateActive
+		scheduleObj.Status.Request = name
+		scheduleObj.Status.ApprovedBy = []string{"admin"}
+		Expect(k8sClient.Status().Update(ctx, scheduleObj)).To(Succeed())
+
+		Eventually(func() (v1alpha1.ScheduleState, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+			return scheduleObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.ScheduleStateActive))
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(scheduleObj)).Should(Succeed())
+
+		Eventually(func() (string, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+			return scheduleObj.Status.LastGrant, err
+		}, 5*time.Second, 500*time.Millisecond).ShouldNot(BeEmpty())
+
+		grantObj := &v1alpha1.AccessGrant{}
+		waitForCreated(ctx, k8sClient, client.ObjectKey{Name: scheduleObj.Status.LastGrant, Namespace: "default"}, grantObj)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, grantObj))).To(Succeed())
+		})
+
+		// Wait for the cache to index the grant by its incident
+		Eventually(func() (int, error) {
+			grants := &v1alpha1.AccessGrantList{}
+			err := k8sClient.List(ctx, grants, client.MatchingFields{"status.incidentMode": name})
+			return len(grants.Items), err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(1))
+
+		incidentReconciler := &IncidentModeReconciler{
+			Client:         mgr.GetClient(),
+			Scheme:         scheme.Scheme,
+			Recorder:       mgr.GetEventRecorder("incidentmode-controller"),
+			PolicyManagers: []*policy.PolicyManager{reconciler.PolicyManager},
+		}
+		incidentReconciler.Processor = &processors.IncidentProcessor{
+			Client:         incidentReconciler.Client,
+			Scheme:         incidentReconciler.Scheme,
+			Recorder:       incidentReconciler.Recorder,
+			PolicyManagers: incidentReconciler.PolicyManagers,
+		}
+
+		reconcileOnce(ctx, incidentReconciler, client.ObjectKeyFromObject(incidentObj)).Should(Succeed())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)).To(Succeed())
+		incidentObj.Spec.Closed = true
+		Expect(k8sClient.Update(ctx, incidentObj)).To(Succeed())
+
+		// Reconcile until the cache has seen the incident being closed
+		Eventually(func() (v1alpha1.IncidentModeState, error) {
+			if _, err := incidentReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(incidentObj)}); err != nil {
+				return "", err
+			}
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)
+			return incidentObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.IncidentModeStateClosed))
+		Expect(incidentObj.Status.RevokedGrants).To(Equal(1))
+
+		Eventually(func() (*v1alpha1.GrantRevocation, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(grantObj), grantObj)
+			return grantObj.Status.Revocation, err
+		}, 5*time.Second, 500*time.Millisecond).ShouldNot(BeNil())
+		Expect(grantObj.Status.Revocation.RevokedBy).To(Equal("incident-mode:" + name))
+	})
+})
@@ -26,7 +26,9 @@ import (
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/processors"
@@ -44,6 +46,8 @@ type ClusterAccessGrantReconciler struct {
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessgrants/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessgrants/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessresponses,verbs=get;list;watch
+
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 func (r *ClusterAccessGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -87,6 +91,17 @@ func (r *ClusterAccessGrantReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&accessv1alpha1.ClusterAccessGrant{}).
+		// Grants share their request's name, so a revocation of the request revokes its grant
+		Watches(
+			&accessv1alpha1.ClusterAccessResponse{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				resp := obj.(*accessv1alpha1.ClusterAccessResponse)
+				if resp.Spec.Response != accessv1alpha1.ResponseStateRevoked {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: resp.Spec.RequestRef}}}
+			}),
+		).
 		Named("clustergrant-controller").
 		Complete(r)
 }
@@ -23,6 +23,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,17 +34,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/oncall"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
 	"github.com/itsthatdude/jit-access-controller/internal/processors"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
 )
 
 // ClusterAccessRequestReconciler reconciles a ClusterAccessRequest object
 type ClusterAccessRequestReconciler struct {
 	client.Client
-	Scheme         *runtime.Scheme
-	PolicyManager  *policy.PolicyManager
-	PolicyResolver *policy.PolicyResolver
-	Processor      *processors.RequestProcessor
+	Scheme          *runtime.Scheme
+	Recorder        events.EventRecorder
+	PolicyManager   *policy.PolicyManager
+	PolicyResolver  *policy.PolicyResolver
+	TicketValidator *ticket.Validator
+	OnCall          *oncall.Resolver
+	Processor       *processors.RequestProcessor
 }
 
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccesspolicies,verbs=get;list;watch;create;update;patch;delete
@@ -58,6 +64,8 @@ type ClusterAccessRequestReconciler struct {
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessresponses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessresponses/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=clusterbreakglassreviews,verbs=get;list;watch;create;update;patch;delete
+
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;delete;bind;escalate
 
@@ -79,10 +87,13 @@ func (r *ClusterAccessRequestReconciler) Reconcile(ctx context.Context, req ctrl
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterAccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Processor = &processors.RequestProcessor{
-		Client:         r.Client,
-		Scheme:         r.Scheme,
-		PolicyManager:  r.PolicyManager,
-		PolicyResolver: r.PolicyResolver,
+		Client:          r.Client,
+		Scheme:          r.Scheme,
+		Recorder:        r.Recorder,
+		PolicyManager:   r.PolicyManager,
+		PolicyResolver:  r.PolicyResolver,
+		TicketValidator: r.TicketValidator,
+		OnCall:          r.OnCall,
 	}
 
 	ctx := context.Background()
@@ -114,6 +125,19 @@ func (r *ClusterAccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) erro
 		return fmt.Errorf("failed to add index for requestRef: %w", err)
 	}
 
+	if err := indexer.IndexField(ctx, &v1alpha1.ClusterBreakGlassReview{}, "spec.requestRef",
+		func(obj client.Object) []string {
+			if myObj, ok := obj.(*v1alpha1.ClusterBreakGlassReview); ok {
+				if myObj.Spec.RequestRef == "" {
+					return nil
+				}
+				return []string{myObj.Spec.RequestRef}
+			}
+			return nil
+		}); err != nil {
+		return fmt.Errorf("failed to add index for review requestRef: %w", err)
+	}
+
 	eventFilter := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
 			return true
@@ -140,6 +164,18 @@ func (r *ClusterAccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			}),
 			builder.WithPredicates(eventFilter),
 		).
+		Watches(
+			&v1alpha1.ClusterBreakGlassReview{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				review := obj.(*v1alpha1.ClusterBreakGlassReview)
+				return []reconcile.Request{{
+					NamespacedName: types.NamespacedName{
+						Name: review.Spec.RequestRef,
+					},
+				}}
+			}),
+			builder.WithPredicates(eventFilter),
+		).
 		Named("clusterrequest-controller").
 		Complete(r)
 }
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("ClusterAccessSchedule Controller", func() {
+	var (
+		ctx        context.Context
+		reconciler *ClusterAccessScheduleReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		reconciler = &ClusterAccessScheduleReconciler{
+			Client:         mgr.GetClient(),
+			Scheme:         scheme.Scheme,
+			Recorder:       mgr.GetEventRecorder("accessschedule-controller"),
+			PolicyManager:  policy.NewPolicyManager(),
+			PolicyResolver: &policy.PolicyResolver{},
+		}
+
+		reconciler.Processor = &processors.ScheduleProcessor{
+			Client:         reconciler.Client,
+			Scheme:         reconciler.Scheme,
+			Recorder:       reconciler.Recorder,
+			PolicyManager:  reconciler.PolicyManager,
+			PolicyResolver: reconciler.PolicyResolver,
+		}
+	})
+
+	It("should create a request to approve the ClusterAccessSchedule", func() {
+		scheduleName := fmt.Sprintf("test-schedule-%d", time.Now().UnixNano())
+
+		scheduleObj := &v1alpha1.ClusterAccessSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: scheduleName,
+			},
+			Spec: v1alpha1.ClusterAccessScheduleSpec{
+				AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+					Subject:       "user1",
+					Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: "edit"},
+					Schedule:      "0 9 * * MON",
+					Duration:      "8h",
+					Justification: "Weekly release",
+				},
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, scheduleObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(scheduleObj)).Should(Succeed())
+
+		requestObj := &v1alpha1.ClusterAccessRequest{}
+		waitForCreated(ctx, k8sClient, client.ObjectKey{Name: scheduleName}, requestObj)
+		Expect(requestObj.Spec.AccessSchedule).To(Equal(scheduleName))
+		Expect(requestObj.Spec.Subject).To(Equal("user1"))
+
+		Eventually(func() (v1alpha1.ScheduleState, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(scheduleObj), scheduleObj)
+			return scheduleObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.ScheduleStatePending))
+
+		Expect(k8sClient.Delete(ctx, scheduleObj)).To(Succeed())
+	})
+})
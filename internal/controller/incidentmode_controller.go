@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
+)
+
+// IncidentModeReconciler reconciles a IncidentMode object
+type IncidentModeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+	// PolicyManagers hold the policies the incidents' overrides apply to
+	PolicyManagers []*policy.PolicyManager
+	Processor      *processors.IncidentProcessor
+}
+
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=incidentmodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=incidentmodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=incidentmodes/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=accessgrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=access.antware.xyz,resources=clusteraccessgrants/status,verbs=get;update;patch
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+func (r *IncidentModeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = logf.FromContext(ctx)
+
+	var obj accessv1alpha1.IncidentMode
+	err := r.Get(ctx, req.NamespacedName, &obj)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	return r.Processor.ReconcileIncident(ctx, &obj)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IncidentModeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Processor = &processors.IncidentProcessor{
+		Client:         r.Client,
+		Scheme:         r.Scheme,
+		Recorder:       r.Recorder,
+		PolicyManagers: r.PolicyManagers,
+	}
+
+	ctx := context.Background()
+	indexer := mgr.GetFieldIndexer()
+
+	// Grants created under an incident are revoked when it closes
+	incidentMode := func(obj client.Object) []string {
+		grant, ok := obj.(common.AccessGrantObject)
+		if !ok || grant.GetStatus().IncidentMode == "" {
+			return nil
+		}
+		return []string{grant.GetStatus().IncidentMode}
+	}
+	if err := indexer.IndexField(ctx, &accessv1alpha1.AccessGrant{}, "status.incidentMode", incidentMode); err != nil {
+		return fmt.Errorf("failed to add index for incidentMode: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &accessv1alpha1.ClusterAccessGrant{}, "status.incidentMode", incidentMode); err != nil {
+		return fmt.Errorf("failed to add index for incidentMode: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&accessv1alpha1.IncidentMode{}).
+		Named("incidentmode-controller").
+		Complete(r)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/processors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("IncidentMode Controller", func() {
+	var (
+		ctx        context.Context
+		reconciler *IncidentModeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		reconciler = &IncidentModeReconciler{
+			Client:         mgr.GetClient(),
+			Scheme:         scheme.Scheme,
+			Recorder:       mgr.GetEventRecorder("incidentmode-controller"),
+			PolicyManagers: []*policy.PolicyManager{policy.NewPolicyManager()},
+		}
+
+		reconciler.Processor = &processors.IncidentProcessor{
+			Client:         reconciler.Client,
+			Scheme:         reconciler.Scheme,
+			Recorder:       reconciler.Recorder,
+			PolicyManagers: reconciler.PolicyManagers,
+		}
+	})
+
+	It("should open and close the IncidentMode", func() {
+		incidentName := fmt.Sprintf("test-incident-%d", time.Now().UnixNano())
+		requiredApprovals := 0
+
+		incidentObj := &v1alpha1.IncidentMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: incidentName,
+			},
+			Spec: v1alpha1.IncidentModeSpec{
+				Description: "Payments API outage",
+				Overrides:   v1alpha1.IncidentOverrides{RequiredApprovals: &requiredApprovals},
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, incidentObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(incidentObj), incidentObj)
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(incidentObj)).Should(Succeed())
+
+		Eventually(func() (v1alpha1.IncidentModeState, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)
+			return incidentObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.IncidentModeStateActive))
+
+		incidentObj.Spec.Closed = true
+		Expect(k8sClient.Update(ctx, incidentObj)).To(Succeed())
+
+		// Reconcile until the cache has seen the incident being closed
+		Eventually(func() (v1alpha1.IncidentModeState, error) {
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(incidentObj)}); err != nil {
+				return "", err
+			}
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)
+			return incidentObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.IncidentModeStateClosed))
+		Expect(incidentObj.Status.ClosedAt.IsZero()).To(BeFalse())
+
+		Expect(k8sClient.Delete(ctx, incidentObj)).To(Succeed())
+	})
+
+	It("should revoke the grants created under the IncidentMode when it closes", func() {
+		incidentName := fmt.Sprintf("test-incident-%d", time.Now().UnixNano())
+		otherIncidentName := incidentName + "-other"
+
+		incidentObj := &v1alpha1.IncidentMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: incidentName,
+			},
+			Spec: v1alpha1.IncidentModeSpec{
+				Description:   "Payments API outage",
+				RevokeOnClose: true,
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, incidentObj)).To(Succeed())
+		waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(incidentObj), incidentObj)
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, incidentObj))).To(Succeed())
+		})
+
+		reconcileOnce(ctx, reconciler, client.ObjectKeyFromObject(incidentObj)).Should(Succeed())
+
+		// One grant created under the incident, one under another incident
+		createGrant := func(name, incident string) *v1alpha1.AccessGrant {
+			grantObj := &v1alpha1.AccessGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, grantObj)).To(Succeed())
+			waitForCreated(ctx, k8sClient, client.ObjectKeyFromObject(grantObj), grantObj)
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, grantObj))).To(Succeed())
+			})
+
+			grantObj.Status.ApprovedBy = []string{"admin"}
+			grantObj.Status.RequestId = name
+			grantObj.Status.Role = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: "edit"}
+			grantObj.Status.Subject = "user1"
+			grantObj.Status.Duration = "10m"
+			grantObj.Status.IncidentMode = incident
+
+			Expect(k8sClient.Status().Update(ctx, grantObj)).To(Succeed())
+			return grantObj
+		}
+		grantObj := createGrant(incidentName, incidentName)
+		otherGrantObj := createGrant(otherIncidentName, otherIncidentName)
+
+		// Wait for the cache to index the grant by its incident
+		Eventually(func() (int, error) {
+			grants := &v1alpha1.AccessGrantList{}
+			err := k8sClient.List(ctx, grants, client.MatchingFields{"status.incidentMode": incidentName})
+			return len(grants.Items), err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(1))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)).To(Succeed())
+		incidentObj.Spec.Closed = true
+		Expect(k8sClient.Update(ctx, incidentObj)).To(Succeed())
+
+		// Reconcile until the cache has seen the incident being closed
+		Eventually(func() (v1alpha1.IncidentModeState, error) {
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(incidentObj)}); err != nil {
+				return "", err
+			}
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(incidentObj), incidentObj)
+			return incidentObj.Status.State, err
+		}, 5*time.Second, 500*time.Millisecond).Should(Equal(v1alpha1.IncidentModeStateClosed))
+		Expect(incidentObj.Status.RevokedGrants).To(Equal(1))
+
+		Eventually(func() (*v1alpha1.GrantRevocation, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(grantObj), grantObj)
+			return grantObj.Status.Revocation, err
+		}, 5*time.Second, 500*time.Millisecond).ShouldNot(BeNil())
+		Expect(grantObj.Status.Revocation.RevokedBy).To(Equal("incident-mode:" + incidentName))
+		Expect(grantObj.Status.Revocation.Reason).To(Equal(fmt.Sprintf("incident %s was closed", incidentName)))
+
+		// Grants created under other incidents are left alone
+		Consistently(func() (*v1alpha1.GrantRevocation, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(otherGrantObj), otherGrantObj)
+			return otherGrantObj.Status.Revocation, err
+		}, 2*time.Second, 500*time.Millisecond).Should(BeNil())
+	})
+})
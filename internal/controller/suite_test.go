@@ -107,6 +107,22 @@ var _ = BeforeSuite(func() {
 			return nil
 		})).To(Succeed())
 
+	Expect(mgr.GetFieldIndexer().IndexField(ctx, &accessv1alpha1.AccessGrant{}, "status.incidentMode",
+		func(obj client.Object) []string {
+			if g, ok := obj.(*accessv1alpha1.AccessGrant); ok && g.Status.IncidentMode != "" {
+				return []string{g.Status.IncidentMode}
+			}
+			return nil
+		})).To(Succeed())
+
+	Expect(mgr.GetFieldIndexer().IndexField(ctx, &accessv1alpha1.ClusterAccessGrant{}, "status.incidentMode",
+		func(obj client.Object) []string {
+			if g, ok := obj.(*accessv1alpha1.ClusterAccessGrant); ok && g.Status.IncidentMode != "" {
+				return []string{g.Status.IncidentMode}
+			}
+			return nil
+		})).To(Succeed())
+
 	go func() {
 		Expect(mgr.Start(ctx)).To(Succeed())
 	}()
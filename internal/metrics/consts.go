@@ -1,7 +1,8 @@
 package metrics
 
 const (
-	MetricStatePending  = 0
-	MetricStateApproved = 1
-	MetricStateDenied   = 2
+	MetricStatePending       = 0
+	MetricStateApproved      = 1
+	MetricStateDenied        = 2
+	MetricStatePendingReview = 3
 )
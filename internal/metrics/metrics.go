@@ -33,7 +33,7 @@ var (
 		prometheus.GaugeOpts{
 			Namespace: metricNamespace,
 			Name:      "request_status",
-			Help:      "Status of access requests (0: pending, 1: approved, 2: denied)",
+			Help:      "Status of access requests (0: pending, 1: approved, 2: denied, 3: pending review)",
 		},
 		[]string{"scope", "target_namespace", "request", "subject"},
 	)
@@ -64,6 +64,42 @@ var (
 		},
 		[]string{"scope", "target_namespace", "grant", "subject"},
 	)
+
+	GrantsRevoked = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "grants_revoked",
+			Help:      "Number of grants revoked or released before they expired",
+		},
+		[]string{"scope", "target_namespace", "subject", "action"},
+	)
+
+	BreakGlassGrants = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "break_glass_grants",
+			Help:      "Number of break-glass requests granted without approvals",
+		},
+		[]string{"scope", "target_namespace", "subject"},
+	)
+
+	BreakGlassReviews = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "break_glass_reviews",
+			Help:      "Number of break-glass requests reviewed, by outcome",
+		},
+		[]string{"scope", "target_namespace", "subject", "outcome"},
+	)
+
+	ScheduledGrants = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "scheduled_grants",
+			Help:      "Number of access schedule occurrences, by outcome",
+		},
+		[]string{"scope", "target_namespace", "schedule", "subject", "outcome"},
+	)
 )
 
 func RegisterMetrics(version string) {
@@ -86,4 +122,10 @@ func RegisterMetrics(version string) {
 	k8smetrics.Registry.MustRegister(PermissionsGranted)
 
 	k8smetrics.Registry.MustRegister(GrantDuration)
+	k8smetrics.Registry.MustRegister(GrantsRevoked)
+
+	k8smetrics.Registry.MustRegister(BreakGlassGrants)
+	k8smetrics.Registry.MustRegister(BreakGlassReviews)
+
+	k8smetrics.Registry.MustRegister(ScheduledGrants)
 }
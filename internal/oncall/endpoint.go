@@ -0,0 +1,124 @@
+package oncall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LookupRequest is sent to the on-call endpoint
+type LookupRequest struct {
+	Roster  string    `json:"roster"`
+	Subject string    `json:"subject"`
+	Groups  []string  `json:"groups,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// LookupResponse is returned by the on-call endpoint. An unknown roster is
+// reported with a 404 status.
+type LookupResponse struct {
+	OnCall bool        `json:"onCall"`
+	User   string      `json:"user,omitempty"`
+	Start  metav1.Time `json:"start,omitempty"`
+	End    metav1.Time `json:"end,omitempty"`
+}
+
+// Endpoint looks up on-call rosters with an external HTTP endpoint
+type Endpoint struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func NewEndpoint(url string, timeout time.Duration) *Endpoint {
+	return &Endpoint{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Lookup asks the endpoint whether the subject is on call in the roster at
+// the given time. It returns nil when the subject isn't on call.
+func (e *Endpoint) Lookup(
+	ctx context.Context,
+	roster, subject string,
+	groups []string,
+	now time.Time,
+) (*accessv1alpha1.OnCallEntry, error) {
+	if e == nil || e.URL == "" {
+		return nil, fmt.Errorf("the policy requires an HTTP on-call roster but no on-call endpoint is configured")
+	}
+
+	resp, err := e.post(ctx, LookupRequest{
+		Roster:  roster,
+		Subject: subject,
+		Groups:  groups,
+		Time:    now.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRoster, roster)
+	}
+
+	if !resp.OnCall {
+		return nil, nil
+	}
+	if resp.User != subject {
+		return nil, fmt.Errorf("on-call endpoint returned a shift for %s instead of %s", resp.User, subject)
+	}
+
+	return &accessv1alpha1.OnCallEntry{
+		Roster: roster,
+		Source: accessv1alpha1.OnCallSourceHTTP,
+		User:   resp.User,
+		Start:  resp.Start,
+		End:    resp.End,
+	}, nil
+}
+
+func (e *Endpoint) post(ctx context.Context, payload LookupRequest) (*LookupResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode on-call request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create on-call request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := e.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call on-call endpoint: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 512))
+		return nil, fmt.Errorf("on-call endpoint returned %s: %s", httpResp.Status, bytes.TrimSpace(msg))
+	}
+
+	resp := &LookupResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode on-call response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpointLookup(t *testing.T) {
+	start := metav1.NewTime(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC))
+
+	var received LookupRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch received.Roster {
+		case "missing":
+			http.NotFound(w, r)
+		case "broken":
+			http.Error(w, "backend unavailable", http.StatusBadGateway)
+		default:
+			resp := LookupResponse{}
+			switch received.Subject {
+			case "alice":
+				resp = LookupResponse{OnCall: true, User: "alice", Start: start, End: end}
+			case "mallory":
+				resp = LookupResponse{OnCall: true, User: "alice", Start: start, End: end}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer server.Close()
+
+	endpoint := NewEndpoint(server.URL, 5*time.Second)
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		roster   string
+		subject  string
+		wantUser string
+		wantErr  error
+		err      bool
+	}{
+		{name: "subject on call", roster: "payments-primary", subject: "alice", wantUser: "alice"},
+		{name: "subject off call", roster: "payments-primary", subject: "bob"},
+		{name: "shift for another user", roster: "payments-primary", subject: "mallory", err: true},
+		{name: "unknown roster", roster: "missing", subject: "alice", wantErr: ErrUnknownRoster},
+		{name: "endpoint failure", roster: "broken", subject: "alice", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := endpoint.Lookup(context.Background(), tt.roster, tt.subject, []string{"sre"}, now)
+
+			if received.Roster != tt.roster || received.Subject != tt.subject || !received.Time.Equal(now) {
+				t.Errorf("unexpected request sent to the endpoint: %+v", received)
+			}
+
+			if tt.wantErr != nil || tt.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ""
+			if entry != nil {
+				got = entry.User
+				if !entry.Start.Equal(&start) || !entry.End.Equal(&end) {
+					t.Errorf("unexpected shift: %+v", entry)
+				}
+			}
+			if got != tt.wantUser {
+				t.Errorf("Lookup() user = %q, want %q", got, tt.wantUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// RosterKey is the ConfigMap key holding the roster's shifts
+const RosterKey = "roster.yaml"
+
+// ErrUnknownRoster is returned when the roster doesn't exist in its source
+var ErrUnknownRoster = errors.New("unknown on-call roster")
+
+// Shift is an entry in a ConfigMap roster
+type Shift struct {
+	User  string      `json:"user"`
+	Start metav1.Time `json:"start"`
+	End   metav1.Time `json:"end"`
+}
+
+// Resolver looks up whether a subject is on call in a roster
+type Resolver struct {
+	// Client reads ConfigMap rosters. The controller can read ConfigMaps in its
+	// own namespace, so an uncached reader avoids watching ConfigMaps cluster-wide.
+	Client client.Reader
+	// Namespace holds the ConfigMap rosters
+	Namespace string
+	// Endpoint is queried for HTTP rosters
+	Endpoint *Endpoint
+}
+
+// Lookup returns the roster entry that has the subject on call at the given
+// time, or nil when the subject isn't on call.
+func (r *Resolver) Lookup(
+	ctx context.Context,
+	requirement *accessv1alpha1.OnCallRequirement,
+	subject string,
+	groups []string,
+	now time.Time,
+) (*accessv1alpha1.OnCallEntry, error) {
+	if r == nil {
+		return nil, fmt.Errorf("no on-call source is configured")
+	}
+
+	switch requirement.Source {
+	case accessv1alpha1.OnCallSourceHTTP:
+		return r.Endpoint.Lookup(ctx, requirement.Roster, subject, groups, now)
+	case accessv1alpha1.OnCallSourceConfigMap, "":
+		return r.lookupConfigMap(ctx, requirement.Roster, subject, now)
+	default:
+		return nil, fmt.Errorf("unsupported on-call source %q", requirement.Source)
+	}
+}
+
+func (r *Resolver) lookupConfigMap(ctx context.Context, roster, subject string, now time.Time) (*accessv1alpha1.OnCallEntry, error) {
+	if r.Client == nil {
+		return nil, fmt.Errorf("no client is configured for ConfigMap rosters")
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: roster, Namespace: r.Namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: ConfigMap %s/%s not found", ErrUnknownRoster, r.Namespace, roster)
+		}
+		return nil, fmt.Errorf("failed to get on-call roster %s: %w", roster, err)
+	}
+
+	shifts, err := ParseRoster(cm.Data[RosterKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid on-call roster %s: %w", roster, err)
+	}
+
+	shift := Current(shifts, subject, now)
+	if shift == nil {
+		return nil, nil
+	}
+
+	return &accessv1alpha1.OnCallEntry{
+		Roster: roster,
+		Source: accessv1alpha1.OnCallSourceConfigMap,
+		User:   shift.User,
+		Start:  shift.Start,
+		End:    shift.End,
+	}, nil
+}
+
+// ParseRoster parses the shifts of a ConfigMap roster
+func ParseRoster(data string) ([]Shift, error) {
+	var shifts []Shift
+	if err := yaml.UnmarshalStrict([]byte(data), &shifts); err != nil {
+		return nil, err
+	}
+
+	for i, shift := range shifts {
+		if shift.User == "" {
+			return nil, fmt.Errorf("shift %d has no user", i)
+		}
+		if !shift.End.After(shift.Start.Time) {
+			return nil, fmt.Errorf("shift %d for %s ends before it starts", i, shift.User)
+		}
+	}
+
+	return shifts, nil
+}
+
+// Current returns the subject's shift that covers the given time, or nil
+func Current(shifts []Shift, subject string, now time.Time) *Shift {
+	for i := range shifts {
+		shift := &shifts[i]
+		if shift.User == subject && !now.Before(shift.Start.Time) && now.Before(shift.End.Time) {
+			return shift
+		}
+	}
+	return nil
+}
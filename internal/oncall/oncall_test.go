@@ -0,0 +1,135 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLookupConfigMap(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	roster := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-primary", Namespace: "jit-access"},
+		Data: map[string]string{RosterKey: `
+- user: alice
+  start: "2026-10-17T08:00:00Z"
+  end: "2026-10-17T20:00:00Z"
+- user: bob
+  start: "2026-10-17T20:00:00Z"
+  end: "2026-10-18T08:00:00Z"
+`},
+	}
+	broken := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "jit-access"},
+		Data:       map[string]string{RosterKey: "- user: alice\n  shift: day\n"},
+	}
+
+	resolver := &Resolver{
+		Client:    ctrlclient.NewClientBuilder().WithScheme(sch).WithObjects(roster, broken).Build(),
+		Namespace: "jit-access",
+	}
+
+	morning := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		roster   string
+		subject  string
+		now      time.Time
+		wantUser string
+		wantErr  error
+		err      bool
+	}{
+		{name: "subject on call", roster: "payments-primary", subject: "alice", now: morning, wantUser: "alice"},
+		{name: "subject off call", roster: "payments-primary", subject: "alice", now: night},
+		{name: "next shift", roster: "payments-primary", subject: "bob", now: night, wantUser: "bob"},
+		{name: "subject not in roster", roster: "payments-primary", subject: "carol", now: morning},
+		{name: "missing roster", roster: "missing", subject: "alice", now: morning, wantErr: ErrUnknownRoster},
+		{name: "invalid roster", roster: "broken", subject: "alice", now: morning, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requirement := &accessv1alpha1.OnCallRequirement{Roster: tt.roster, Source: accessv1alpha1.OnCallSourceConfigMap}
+			entry, err := resolver.Lookup(context.Background(), requirement, tt.subject, nil, tt.now)
+
+			if tt.wantErr != nil || tt.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ""
+			if entry != nil {
+				got = entry.User
+				if entry.Roster != tt.roster || entry.Source != accessv1alpha1.OnCallSourceConfigMap {
+					t.Errorf("unexpected entry: %+v", entry)
+				}
+			}
+			if got != tt.wantUser {
+				t.Errorf("Lookup() user = %q, want %q", got, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestLookupWithoutSource(t *testing.T) {
+	var resolver *Resolver
+	requirement := &accessv1alpha1.OnCallRequirement{Roster: "payments-primary"}
+	if _, err := resolver.Lookup(context.Background(), requirement, "alice", nil, time.Now()); err == nil {
+		t.Errorf("expected an error without a resolver")
+	}
+
+	resolver = &Resolver{}
+	requirement.Source = accessv1alpha1.OnCallSourceHTTP
+	if _, err := resolver.Lookup(context.Background(), requirement, "alice", nil, time.Now()); err == nil {
+		t.Errorf("expected an error without an on-call endpoint")
+	}
+}
+
+func TestParseRoster(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty roster", data: "", want: 0},
+		{name: "valid roster", data: "- user: alice\n  start: \"2026-10-17T08:00:00Z\"\n  end: \"2026-10-17T20:00:00Z\"\n", want: 1},
+		{name: "shift without user", data: "- start: \"2026-10-17T08:00:00Z\"\n  end: \"2026-10-17T20:00:00Z\"\n", wantErr: true},
+		{name: "shift ending before it starts", data: "- user: alice\n  start: \"2026-10-17T20:00:00Z\"\n  end: \"2026-10-17T08:00:00Z\"\n", wantErr: true},
+		{name: "invalid time", data: "- user: alice\n  start: tomorrow\n  end: \"2026-10-17T20:00:00Z\"\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifts, err := ParseRoster(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRoster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(shifts) != tt.want {
+				t.Errorf("ParseRoster() returned %d shifts, want %d", len(shifts), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"log"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	plugin "github.com/itsthatdude/jit-access-controller/internal/plugin/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spf13/cobra"
+)
+
+func NewExtendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "extend <grant_name>",
+		Short: "Request an extension of an active grant",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli, err := plugin.GetRuntimeClient()
+			if err != nil {
+				return err
+			}
+
+			justificationFields, err := plugin.ParseJustificationFields(fields)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			spec := v1alpha1.AccessRequestBaseSpec{
+				Extends:             args[0],
+				Duration:            duration,
+				Justification:       justification,
+				JustificationFields: justificationFields,
+			}
+
+			if scope == plugin.SCOPE_CLUSTER {
+				req := &v1alpha1.ClusterAccessRequest{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "access-extension-"},
+					Spec:       v1alpha1.ClusterAccessRequestSpec{AccessRequestBaseSpec: spec},
+				}
+				if err := cli.Create(ctx, req); err != nil {
+					return err
+				}
+				log.Printf("ClusterAccessRequest created to extend %s: %s\n", args[0], req.Name)
+			} else {
+				req := &v1alpha1.AccessRequest{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "access-extension-", Namespace: namespace},
+					Spec:       v1alpha1.AccessRequestSpec{AccessRequestBaseSpec: spec},
+				}
+				if err := cli.Create(ctx, req); err != nil {
+					return err
+				}
+				log.Printf("AccessRequest created to extend %s: %s/%s\n", args[0], namespace, req.Name)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the grant")
+	cmd.Flags().StringVar(&scope, "scope", "namespace", "Scope of the grant (namespace|cluster)")
+	cmd.Flags().StringVar(&duration, "duration", "", "How long to extend the grant by (e.g. 30m), defaults to the policy's defaultDuration")
+	cmd.Flags().StringVar(&justification, "justification", "", "Justification for the extension")
+	cmd.Flags().StringArrayVar(&fields, "field", []string{}, "Justification field required by the policy (key=value)")
+
+	return cmd
+}
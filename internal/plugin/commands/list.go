@@ -41,6 +41,21 @@ func NewListCmd() *cobra.Command {
 
 					fmt.Printf("  - Name: %s\n", r.Name)
 					fmt.Printf("    Subject: %s\n", r.Spec.Subject)
+					if r.Spec.Delegator != "" {
+						fmt.Printf("    Requested by: %s\n", r.Spec.Delegator)
+					}
+					if r.Spec.Extends != "" {
+						fmt.Printf("    Extends: %s\n", r.Spec.Extends)
+					}
+					if r.Spec.BreakGlass != nil {
+						fmt.Printf("    Break-glass incident: %s\n", r.Spec.BreakGlass.Incident)
+					}
+					if r.Spec.AccessSchedule != "" {
+						fmt.Printf("    Access schedule: %s\n", r.Spec.AccessSchedule)
+					}
+					if r.Spec.StartAt != nil {
+						fmt.Printf("    Starts at: %s\n", r.Spec.StartAt.String())
+					}
 					fmt.Printf("    State: %s\n", r.Status.State)
 					if state != v1alpha1.RequestStateApproved {
 						fmt.Printf("    Expires: %s\n", r.Status.RequestExpiresAt.String())
@@ -65,6 +80,21 @@ func NewListCmd() *cobra.Command {
 
 					fmt.Printf("  - Name: %s\n", r.Name)
 					fmt.Printf("    Subject: %s\n", r.Spec.Subject)
+					if r.Spec.Delegator != "" {
+						fmt.Printf("    Requested by: %s\n", r.Spec.Delegator)
+					}
+					if r.Spec.Extends != "" {
+						fmt.Printf("    Extends: %s\n", r.Spec.Extends)
+					}
+					if r.Spec.BreakGlass != nil {
+						fmt.Printf("    Break-glass incident: %s\n", r.Spec.BreakGlass.Incident)
+					}
+					if r.Spec.AccessSchedule != "" {
+						fmt.Printf("    Access schedule: %s\n", r.Spec.AccessSchedule)
+					}
+					if r.Spec.StartAt != nil {
+						fmt.Printf("    Starts at: %s\n", r.Spec.StartAt.String())
+					}
 					fmt.Printf("    State: %s\n", r.Status.State)
 					if state != v1alpha1.RequestStateApproved {
 						fmt.Printf("    Expires: %s\n", r.Status.RequestExpiresAt.String())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/common"
@@ -28,6 +29,26 @@ func NewRequestCmd() *cobra.Command {
 				return fmt.Errorf("a role or adhoc permissions must be specified")
 			}
 
+			justificationFields, err := plugin.ParseJustificationFields(fields)
+			if err != nil {
+				return err
+			}
+
+			target, err := plugin.ParseGrantTarget(grantTo)
+			if err != nil {
+				return err
+			}
+
+			start, err := plugin.ParseStartAt(startAt, time.Now())
+			if err != nil {
+				return err
+			}
+
+			var breakGlass *v1alpha1.BreakGlassRequest
+			if incident != "" {
+				breakGlass = &v1alpha1.BreakGlassRequest{Incident: incident}
+			}
+
 			ctx := context.Background()
 			rules := plugin.ParsePermissions(permissions)
 
@@ -47,10 +68,15 @@ func NewRequestCmd() *cobra.Command {
 					},
 					Spec: v1alpha1.ClusterAccessRequestSpec{
 						AccessRequestBaseSpec: v1alpha1.AccessRequestBaseSpec{
-							Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: role},
-							Permissions:   rules,
-							Duration:      duration,
-							Justification: justification,
+							Role:                rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: role},
+							Permissions:         rules,
+							Duration:            duration,
+							Justification:       justification,
+							JustificationFields: justificationFields,
+							GrantTarget:         target,
+							OnBehalfOf:          onBehalfOf,
+							BreakGlass:          breakGlass,
+							StartAt:             start,
 						},
 					},
 				}
@@ -67,10 +93,15 @@ func NewRequestCmd() *cobra.Command {
 					},
 					Spec: v1alpha1.AccessRequestSpec{
 						AccessRequestBaseSpec: v1alpha1.AccessRequestBaseSpec{
-							Role:          rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: roleKindStr, Name: role},
-							Permissions:   rules,
-							Duration:      duration,
-							Justification: justification,
+							Role:                rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: roleKindStr, Name: role},
+							Permissions:         rules,
+							Duration:            duration,
+							Justification:       justification,
+							JustificationFields: justificationFields,
+							GrantTarget:         target,
+							OnBehalfOf:          onBehalfOf,
+							BreakGlass:          breakGlass,
+							StartAt:             start,
 						},
 					},
 				}
@@ -89,8 +120,13 @@ func NewRequestCmd() *cobra.Command {
 	cmd.Flags().StringVar(&role, "role", "", "Role to request")
 	cmd.Flags().StringVar(&roleKindStr, "roleKind", common.RoleKindRole, "Role kind (Role|ClusterRole)")
 	cmd.Flags().StringArrayVar(&permissions, "permissions", []string{}, "List of permissions (verbs:resources)")
-	cmd.Flags().StringVar(&duration, "duration", "1h", "Duration in seconds for the access")
+	cmd.Flags().StringVar(&duration, "duration", "", "Duration of the access (e.g. 30m), defaults to the policy's defaultDuration")
 	cmd.Flags().StringVar(&justification, "justification", "", "Justification for the request")
+	cmd.Flags().StringArrayVar(&fields, "field", []string{}, "Justification field required by the policy (key=value)")
+	cmd.Flags().StringVar(&grantTo, "grant-to", "", "Group or ServiceAccount to grant access to instead of yourself (group:<name>|serviceaccount:[<namespace>/]<name>)")
+	cmd.Flags().StringVar(&onBehalfOf, "on-behalf-of", "", "User to request access for, when a policy allows you to delegate")
+	cmd.Flags().StringVar(&incident, "break-glass", "", "Incident reference to request emergency access without approvals, when a policy allows break-glass access")
+	cmd.Flags().StringVar(&startAt, "start-at", "", "Time to schedule the access for, as an RFC 3339 timestamp or a duration from now (e.g. 48h)")
 
 	return cmd
 }
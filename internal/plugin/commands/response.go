@@ -23,10 +23,10 @@ func newResponseCommand(action string) *cobra.Command {
 				if selection == nil {
 					return fmt.Errorf("the selected request was nil")
 				}
-				return common.CreateResponse(scope, namespace, selection.GetName(), v1alpha1.ResponseStateApproved)
+				return common.CreateResponse(scope, namespace, selection.GetName(), v1alpha1.ResponseStateApproved, "")
 			} else {
 				name := args[0]
-				return common.CreateResponse(scope, namespace, name, v1alpha1.ResponseStateApproved)
+				return common.CreateResponse(scope, namespace, name, v1alpha1.ResponseStateApproved, "")
 			}
 		},
 	}
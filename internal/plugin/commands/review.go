@@ -0,0 +1,31 @@
+package commands
+
+import (
+	plugin "github.com/itsthatdude/jit-access-controller/internal/plugin/common"
+
+	"github.com/spf13/cobra"
+)
+
+func NewReviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review <request_name>",
+		Short: "Review the use of break-glass access",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reviewOutcome, err := plugin.ParseReviewOutcome(outcome)
+			if err != nil {
+				return err
+			}
+
+			return plugin.CreateReview(scope, namespace, args[0], reviewOutcome, comment)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the request")
+	cmd.Flags().StringVar(&scope, "scope", "namespace", "Scope of the request (namespace|cluster)")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "Outcome of the review (acceptable|flagged)")
+	cmd.Flags().StringVar(&comment, "comment", "", "Comment explaining the outcome")
+	_ = cmd.MarkFlagRequired("outcome")
+
+	return cmd
+}
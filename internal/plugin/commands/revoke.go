@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	plugin "github.com/itsthatdude/jit-access-controller/internal/plugin/common"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+func NewRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke <grant_name>",
+		Short: "Revoke an active grant, or release your own access early",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli, err := plugin.GetRuntimeClient()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			var status *v1alpha1.AccessGrantStatus
+			if scope == plugin.SCOPE_CLUSTER {
+				grant := &v1alpha1.ClusterAccessGrant{}
+				if err := cli.Get(ctx, types.NamespacedName{Name: args[0]}, grant); err != nil {
+					return err
+				}
+				status = &grant.Status
+			} else {
+				grant := &v1alpha1.AccessGrant{}
+				if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: args[0]}, grant); err != nil {
+					return err
+				}
+				status = &grant.Status
+			}
+
+			return plugin.CreateResponse(scope, namespace, status.Request, v1alpha1.ResponseStateRevoked, reason)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the grant")
+	cmd.Flags().StringVar(&scope, "scope", "namespace", "Scope of the grant (namespace|cluster)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason for revoking the grant")
+
+	return cmd
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/common"
+	plugin "github.com/itsthatdude/jit-access-controller/internal/plugin/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spf13/cobra"
+)
+
+func NewScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Request recurring access on a schedule",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli, err := plugin.GetRuntimeClient()
+			if err != nil {
+				return err
+			}
+
+			if len(permissions) == 0 && role == "" {
+				return fmt.Errorf("a role or adhoc permissions must be specified")
+			}
+			if schedule == "" || duration == "" {
+				return fmt.Errorf("a schedule and a duration must be specified")
+			}
+
+			justificationFields, err := plugin.ParseJustificationFields(fields)
+			if err != nil {
+				return err
+			}
+
+			target, err := plugin.ParseGrantTarget(grantTo)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			rules := plugin.ParsePermissions(permissions)
+
+			if scope == plugin.SCOPE_CLUSTER {
+				s := &v1alpha1.ClusterAccessSchedule{
+					ObjectMeta: metav1.ObjectMeta{
+						GenerateName: "access-schedule-",
+					},
+					Spec: v1alpha1.ClusterAccessScheduleSpec{
+						AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+							Role:                rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindCluster, Name: role},
+							Permissions:         rules,
+							GrantTarget:         target,
+							Schedule:            schedule,
+							TimeZone:            timeZone,
+							Duration:            duration,
+							Justification:       justification,
+							JustificationFields: justificationFields,
+						},
+					},
+				}
+
+				if err := cli.Create(ctx, s); err != nil {
+					return err
+				}
+				log.Printf("ClusterAccessSchedule created: %s, access is granted at each occurrence once request %s is approved\n", s.Name, s.Name)
+			} else {
+				s := &v1alpha1.AccessSchedule{
+					ObjectMeta: metav1.ObjectMeta{
+						GenerateName: "access-schedule-",
+						Namespace:    namespace,
+					},
+					Spec: v1alpha1.AccessScheduleSpec{
+						AccessScheduleBaseSpec: v1alpha1.AccessScheduleBaseSpec{
+							Role:                rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: roleKindStr, Name: role},
+							Permissions:         rules,
+							GrantTarget:         target,
+							Schedule:            schedule,
+							TimeZone:            timeZone,
+							Duration:            duration,
+							Justification:       justification,
+							JustificationFields: justificationFields,
+						},
+					},
+				}
+
+				if err := cli.Create(ctx, s); err != nil {
+					return err
+				}
+				log.Printf("AccessSchedule created: %s/%s, access is granted at each occurrence once request %s is approved\n", namespace, s.Name, s.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace for the access schedule")
+	cmd.Flags().StringVar(&scope, "scope", "namespace", "Scope of the schedule (namespace|cluster)")
+	cmd.Flags().StringVar(&role, "role", "", "Role to request")
+	cmd.Flags().StringVar(&roleKindStr, "roleKind", common.RoleKindRole, "Role kind (Role|ClusterRole)")
+	cmd.Flags().StringArrayVar(&permissions, "permissions", []string{}, "List of permissions (verbs:resources)")
+	cmd.Flags().StringVar(&schedule, "schedule", "", "Cron expression for the start of each occurrence (e.g. \"0 9 * * MON\")")
+	cmd.Flags().StringVar(&timeZone, "time-zone", "UTC", "Time zone the schedule is evaluated in (e.g. Europe/London)")
+	cmd.Flags().StringVar(&duration, "duration", "", "Duration of the access at each occurrence (e.g. 8h)")
+	cmd.Flags().StringVar(&justification, "justification", "", "Justification for the schedule")
+	cmd.Flags().StringArrayVar(&fields, "field", []string{}, "Justification field required by the policy (key=value)")
+	cmd.Flags().StringVar(&grantTo, "grant-to", "", "Group or ServiceAccount to grant access to instead of yourself (group:<name>|serviceaccount:[<namespace>/]<name>)")
+
+	return cmd
+}
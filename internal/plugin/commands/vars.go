@@ -8,4 +8,14 @@ var (
 	permissions   []string
 	duration      string
 	justification string
+	fields        []string
+	grantTo       string
+	onBehalfOf    string
+	reason        string
+	incident      string
+	outcome       string
+	comment       string
+	startAt       string
+	schedule      string
+	timeZone      string
 )
@@ -7,9 +7,12 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	internal "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -61,3 +64,64 @@ func HandleRequestSelection(scope string, namespace string) (internal.AccessRequ
 
 	return options[choice-1], nil
 }
+
+// ParseJustificationFields parses justification fields given as key=value pairs
+func ParseJustificationFields(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	fields := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid justification field %q, expected key=value", pair)
+		}
+		fields[key] = value
+	}
+
+	return fields, nil
+}
+
+// ParseGrantTarget parses the subject to grant access to, given as
+// "group:<name>", "serviceaccount:<name>" or "serviceaccount:<namespace>/<name>"
+func ParseGrantTarget(value string) (*rbacv1.Subject, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	kind, name, ok := strings.Cut(value, ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid grant target %q, expected kind:name", value)
+	}
+
+	switch strings.ToLower(kind) {
+	case "group":
+		return &rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}, nil
+	case "serviceaccount", "sa":
+		namespace, saName, found := strings.Cut(name, "/")
+		if !found {
+			namespace, saName = "", name
+		}
+		return &rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: saName}, nil
+	default:
+		return nil, fmt.Errorf("invalid grant target kind %q, expected group or serviceaccount", kind)
+	}
+}
+
+// ParseStartAt parses the time scheduled access starts at, given as an RFC 3339
+// timestamp (e.g. "2025-06-14T22:00:00Z") or a duration from now (e.g. "48h")
+func ParseStartAt(value string, now time.Time) (*metav1.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &metav1.Time{Time: t}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return &metav1.Time{Time: now.Add(d).Truncate(time.Second)}, nil
+	}
+
+	return nil, fmt.Errorf("invalid start time %q, expected an RFC 3339 timestamp or a duration from now", value)
+}
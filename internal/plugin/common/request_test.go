@@ -0,0 +1,86 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestParseJustificationFields(t *testing.T) {
+	got, err := ParseJustificationFields([]string{"ticket=INC-1", "reason=fix a=b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"ticket": "INC-1", "reason": "fix a=b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fields mismatch: got %v want %v", got, want)
+	}
+
+	if _, err := ParseJustificationFields([]string{"ticket"}); err == nil {
+		t.Errorf("expected an error for a field without a value")
+	}
+}
+
+func TestParseGrantTarget(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    *rbacv1.Subject
+		wantErr bool
+	}{
+		{value: ""},
+		{value: "group:oncall", want: &rbacv1.Subject{Kind: "Group", APIGroup: "rbac.authorization.k8s.io", Name: "oncall"}},
+		{value: "serviceaccount:ci/deployer", want: &rbacv1.Subject{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"}},
+		{value: "sa:deployer", want: &rbacv1.Subject{Kind: "ServiceAccount", Name: "deployer"}},
+		{value: "user:alice", wantErr: true},
+		{value: "group", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseGrantTarget(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGrantTarget(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStartAt(t *testing.T) {
+	now := time.Date(2025, 6, 12, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: ""},
+		{value: "2025-06-14T22:00:00Z", want: time.Date(2025, 6, 14, 22, 0, 0, 0, time.UTC)},
+		{value: "48h", want: now.Add(48 * time.Hour)},
+		{value: "-1h", wantErr: true},
+		{value: "saturday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseStartAt(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got == nil {
+				if !tt.want.IsZero() {
+					t.Errorf("ParseStartAt(%q) = nil, want %v", tt.value, tt.want)
+				}
+				return
+			}
+			if !got.Time.Equal(tt.want) {
+				t.Errorf("ParseStartAt(%q) = %v, want %v", tt.value, got.Time, tt.want)
+			}
+		})
+	}
+}
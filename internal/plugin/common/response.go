@@ -9,7 +9,7 @@ import (
 )
 
 // createResponse creates a AccessResponse or ClusterAccessResponse
-func CreateResponse(scope string, namespace string, requestName string, state v1alpha1.ResponseState) error {
+func CreateResponse(scope string, namespace string, requestName string, state v1alpha1.ResponseState, reason string) error {
 	cli, err := GetRuntimeClient()
 	if err != nil {
 		return err
@@ -24,6 +24,7 @@ func CreateResponse(scope string, namespace string, requestName string, state v1
 			Spec: v1alpha1.AccessResponseSpec{
 				RequestRef: requestName,
 				Response:   state,
+				Reason:     reason,
 			},
 		}
 		if err := cli.Create(ctx, resp); err != nil {
@@ -39,6 +40,7 @@ func CreateResponse(scope string, namespace string, requestName string, state v1
 			Spec: v1alpha1.AccessResponseSpec{
 				RequestRef: requestName,
 				Response:   state,
+				Reason:     reason,
 			},
 		}
 		if err := cli.Create(ctx, resp); err != nil {
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParseReviewOutcome parses the outcome of a break-glass review, given as
+// "acceptable" or "flagged"
+func ParseReviewOutcome(value string) (v1alpha1.ReviewOutcome, error) {
+	switch strings.ToLower(value) {
+	case "acceptable":
+		return v1alpha1.ReviewOutcomeAcceptable, nil
+	case "flagged":
+		return v1alpha1.ReviewOutcomeFlagged, nil
+	default:
+		return "", fmt.Errorf("invalid review outcome %q, expected acceptable or flagged", value)
+	}
+}
+
+// CreateReview creates a BreakGlassReview or ClusterBreakGlassReview
+func CreateReview(scope string, namespace string, requestName string, outcome v1alpha1.ReviewOutcome, comment string) error {
+	cli, err := GetRuntimeClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	spec := v1alpha1.BreakGlassReviewSpec{
+		RequestRef: requestName,
+		Outcome:    outcome,
+		Comment:    comment,
+	}
+
+	if scope == SCOPE_CLUSTER {
+		review := &v1alpha1.ClusterBreakGlassReview{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "review-"},
+			Spec:       spec,
+		}
+		if err := cli.Create(ctx, review); err != nil {
+			return err
+		}
+		log.Printf("ClusterBreakGlassReview created for request %s\n", requestName)
+	} else {
+		review := &v1alpha1.BreakGlassReview{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "review-", Namespace: namespace},
+			Spec:       spec,
+		}
+		if err := cli.Create(ctx, review); err != nil {
+			return err
+		}
+		log.Printf("BreakGlassReview created for request %s/%s\n", namespace, requestName)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+func TestParseReviewOutcome(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    v1alpha1.ReviewOutcome
+		wantErr bool
+	}{
+		{value: "acceptable", want: v1alpha1.ReviewOutcomeAcceptable},
+		{value: "Flagged", want: v1alpha1.ReviewOutcomeFlagged},
+		{value: "approved", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseReviewOutcome(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReviewOutcome(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
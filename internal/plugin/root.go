@@ -15,6 +15,10 @@ var rootCmd = &cobra.Command{
 
 func Init() {
 	rootCmd.AddCommand(commands.NewRequestCmd())
+	rootCmd.AddCommand(commands.NewScheduleCmd())
+	rootCmd.AddCommand(commands.NewExtendCmd())
+	rootCmd.AddCommand(commands.NewRevokeCmd())
+	rootCmd.AddCommand(commands.NewReviewCmd())
 	rootCmd.AddCommand(commands.NewApproveCmd())
 	rootCmd.AddCommand(commands.NewRejectCmd())
 	rootCmd.AddCommand(commands.NewListCmd())
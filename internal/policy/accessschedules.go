@@ -0,0 +1,45 @@
+package policy
+
+import (
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleRequest builds the request an access schedule is approved with.
+// The request has the schedule's name, and startAt is set to check the
+// request against the policy at the start of an occurrence.
+func ScheduleRequest(schedule common.AccessScheduleObject, startAt *metav1.Time) common.AccessRequestObject {
+	spec := schedule.GetSpec()
+
+	base := accessv1alpha1.AccessRequestBaseSpec{
+		Subject:             spec.Subject,
+		Groups:              spec.Groups,
+		Role:                spec.Role,
+		Permissions:         spec.Permissions,
+		GrantTarget:         spec.GrantTarget.DeepCopy(),
+		AccessSchedule:      schedule.GetName(),
+		StartAt:             startAt,
+		Duration:            spec.Duration,
+		Justification:       spec.Justification,
+		JustificationFields: spec.JustificationFields,
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      schedule.GetName(),
+		Namespace: schedule.GetNamespace(),
+		Labels:    common.CommonLabels(),
+	}
+
+	if schedule.GetScope() == accessv1alpha1.RequestScopeCluster {
+		return &accessv1alpha1.ClusterAccessRequest{
+			ObjectMeta: meta,
+			Spec:       accessv1alpha1.ClusterAccessRequestSpec{AccessRequestBaseSpec: base},
+		}
+	}
+
+	return &accessv1alpha1.AccessRequest{
+		ObjectMeta: meta,
+		Spec:       accessv1alpha1.AccessRequestSpec{AccessRequestBaseSpec: base},
+	}
+}
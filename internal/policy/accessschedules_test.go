@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduleRequest(t *testing.T) {
+	spec := accessv1alpha1.AccessScheduleBaseSpec{
+		Subject:       "alice",
+		Groups:        []string{"oncall"},
+		Schedule:      "0 9 * * MON",
+		Duration:      "8h",
+		Justification: "Weekly on-call",
+	}
+	startAt := metav1.NewTime(time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		schedule  common.AccessScheduleObject
+		startAt   *metav1.Time
+		wantScope accessv1alpha1.RequestScope
+	}{
+		{
+			name: "namespaced",
+			schedule: &accessv1alpha1.AccessSchedule{
+				ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "team-a"},
+				Spec:       accessv1alpha1.AccessScheduleSpec{AccessScheduleBaseSpec: spec},
+			},
+			wantScope: accessv1alpha1.RequestScopeNamespace,
+		},
+		{
+			name: "cluster",
+			schedule: &accessv1alpha1.ClusterAccessSchedule{
+				ObjectMeta: metav1.ObjectMeta{Name: "weekly"},
+				Spec:       accessv1alpha1.ClusterAccessScheduleSpec{AccessScheduleBaseSpec: spec},
+			},
+			startAt:   &startAt,
+			wantScope: accessv1alpha1.RequestScopeCluster,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := tt.schedule
+			req := ScheduleRequest(schedule, tt.startAt)
+
+			if req.GetScope() != tt.wantScope {
+				t.Errorf("scope = %s, want %s", req.GetScope(), tt.wantScope)
+			}
+			if req.GetName() != schedule.GetName() || req.GetNamespace() != schedule.GetNamespace() {
+				t.Errorf("request = %s/%s, want %s/%s", req.GetNamespace(), req.GetName(), schedule.GetNamespace(), schedule.GetName())
+			}
+
+			reqSpec := req.GetSpec()
+			if reqSpec.AccessSchedule != "weekly" {
+				t.Errorf("accessSchedule = %q, want %q", reqSpec.AccessSchedule, "weekly")
+			}
+			if reqSpec.Subject != "alice" || reqSpec.Duration != "8h" || reqSpec.Justification != "Weekly on-call" {
+				t.Errorf("spec = %+v, want the schedule's subject, duration and justification", reqSpec)
+			}
+			if reqSpec.StartAt != tt.startAt {
+				t.Errorf("startAt = %v, want %v", reqSpec.StartAt, tt.startAt)
+			}
+		})
+	}
+}
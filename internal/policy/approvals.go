@@ -0,0 +1,202 @@
+package policy
+
+import (
+	"slices"
+	"sort"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Approval is an approving response to a request
+type Approval struct {
+	Approver   string
+	Groups     []string
+	ApprovedAt metav1.Time
+}
+
+// MatchesApprovers returns true if the user, or one of their groups, is in the list of approvers
+func MatchesApprovers(approvers []rbacv1.Subject, user string, groups []string) bool {
+	for _, approver := range approvers {
+		switch approver.Kind {
+		case rbacv1.UserKind:
+			if approver.Name == user {
+				return true
+			}
+		case rbacv1.GroupKind:
+			if slices.Contains(groups, approver.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsSelfApproval returns true if the approver is the request's subject, or the
+// delegator who made the request on the subject's behalf
+func IsSelfApproval(spec accessv1alpha1.AccessRequestBaseSpec, approver string) bool {
+	return approver == spec.Subject || (spec.Delegator != "" && approver == spec.Delegator)
+}
+
+// RequiredApprovals returns the total number of approvals a policy requires
+func RequiredApprovals(spec *accessv1alpha1.SubjectPolicy) int {
+	total := 0
+	if len(spec.ApprovalStages) == 0 {
+		total = spec.RequiredApprovals
+	} else {
+		for _, stage := range spec.ApprovalStages {
+			total += stage.RequiredApprovals
+		}
+	}
+
+	quorum := 0
+	for _, group := range spec.ApprovalQuorum {
+		quorum += group.RequiredApprovals
+	}
+
+	return max(total, quorum)
+}
+
+// CurrentApprovers returns the approvers allowed to respond to a request and
+// the name of the stage they belong to. The stage is empty for policies
+// without approval stages, whose quorum groups are also allowed to respond.
+func CurrentApprovers(spec *accessv1alpha1.SubjectPolicy, status *accessv1alpha1.AccessRequestStatus) ([]rbacv1.Subject, string) {
+	if len(spec.ApprovalStages) == 0 {
+		approvers := slices.Clone(spec.Approvers)
+		for _, quorum := range spec.ApprovalQuorum {
+			approvers = append(approvers, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: quorum.Group})
+		}
+		return approvers, ""
+	}
+
+	for _, stage := range spec.ApprovalStages {
+		if status.CurrentStage == "" || stage.Name == status.CurrentStage {
+			return stage.Approvers, stage.Name
+		}
+	}
+
+	// The stage was removed from the policy, fall back to the first stage
+	stage := spec.ApprovalStages[0]
+	return stage.Approvers, stage.Name
+}
+
+// AllApprovers returns every user and group allowed to approve requests under
+// the policy, in any of its approval stages or quorum groups
+func AllApprovers(spec *accessv1alpha1.SubjectPolicy) []rbacv1.Subject {
+	approvers := slices.Clone(spec.Approvers)
+	for _, stage := range spec.ApprovalStages {
+		approvers = append(approvers, stage.Approvers...)
+	}
+	for _, quorum := range spec.ApprovalQuorum {
+		approvers = append(approvers, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: quorum.Group})
+	}
+	return approvers
+}
+
+// EvaluateStages walks the approval stages in order and assigns each approval,
+// oldest first, to the earliest incomplete stage the approver belongs to. An
+// approver only counts towards one stage. It returns the progress of each
+// stage, the name of the first incomplete stage and whether every stage is
+// complete.
+func EvaluateStages(stages []accessv1alpha1.ApprovalStage, approvals []Approval) ([]accessv1alpha1.ApprovalStageStatus, string, bool) {
+	approvals = slices.Clone(approvals)
+	sort.SliceStable(approvals, func(i, j int) bool {
+		return approvals[i].ApprovedAt.Before(&approvals[j].ApprovedAt)
+	})
+
+	statuses := make([]accessv1alpha1.ApprovalStageStatus, 0, len(stages))
+	counted := map[string]bool{}
+	current := ""
+
+	for _, stage := range stages {
+		stageStatus := accessv1alpha1.ApprovalStageStatus{
+			Name:              stage.Name,
+			ApprovalsRequired: stage.RequiredApprovals,
+		}
+
+		// Later stages can't progress until the current stage is complete
+		if current == "" {
+			for _, approval := range approvals {
+				if stageStatus.ApprovalsReceived >= stage.RequiredApprovals {
+					break
+				}
+				if counted[approval.Approver] || !MatchesApprovers(stage.Approvers, approval.Approver, approval.Groups) {
+					continue
+				}
+				counted[approval.Approver] = true
+				stageStatus.ApprovalsReceived++
+				stageStatus.ApprovedBy = append(stageStatus.ApprovedBy, approval.Approver)
+				stageStatus.CompletedAt = approval.ApprovedAt
+			}
+
+			if stageStatus.ApprovalsReceived < stage.RequiredApprovals {
+				stageStatus.CompletedAt = metav1.Time{}
+				current = stage.Name
+			}
+		}
+
+		statuses = append(statuses, stageStatus)
+	}
+
+	return statuses, current, current == ""
+}
+
+// EvaluateQuorum assigns approvals to the quorum groups their approvers belong
+// to, so that each approval is counted under at most one group. It returns the
+// group each approver was counted under and the groups that haven't reached
+// their required approvals.
+func EvaluateQuorum(quorum []accessv1alpha1.GroupQuorum, approvals []Approval) (map[string]string, []string) {
+	approvals = slices.Clone(approvals)
+	sort.SliceStable(approvals, func(i, j int) bool {
+		return approvals[i].ApprovedAt.Before(&approvals[j].ApprovedAt)
+	})
+
+	// Each required approval is a slot to fill with a member of its group
+	slots := []string{}
+	for _, group := range quorum {
+		for range group.RequiredApprovals {
+			slots = append(slots, group.Group)
+		}
+	}
+
+	// Match approvals to slots, moving earlier approvals to another of their
+	// groups when that frees a slot for a later approval
+	filledBy := make([]int, len(slots))
+	for i := range filledBy {
+		filledBy[i] = -1
+	}
+
+	var assign func(approval int, visited []bool) bool
+	assign = func(approval int, visited []bool) bool {
+		for slot, group := range slots {
+			if visited[slot] || !slices.Contains(approvals[approval].Groups, group) {
+				continue
+			}
+			visited[slot] = true
+			if filledBy[slot] == -1 || assign(filledBy[slot], visited) {
+				filledBy[slot] = approval
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range approvals {
+		assign(i, make([]bool, len(slots)))
+	}
+
+	assigned := map[string]string{}
+	unmet := []string{}
+	for slot, group := range slots {
+		if filledBy[slot] == -1 {
+			if !slices.Contains(unmet, group) {
+				unmet = append(unmet, group)
+			}
+			continue
+		}
+		assigned[approvals[filledBy[slot]].Approver] = group
+	}
+
+	return assigned, unmet
+}
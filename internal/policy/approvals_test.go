@@ -0,0 +1,252 @@
+package policy
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchesApprovers(t *testing.T) {
+	approvers := []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, Name: "alice"},
+		{Kind: rbacv1.GroupKind, Name: "sre"},
+	}
+
+	tests := []struct {
+		name   string
+		user   string
+		groups []string
+		want   bool
+	}{
+		{name: "user match", user: "alice", want: true},
+		{name: "group match", user: "bob", groups: []string{"dev", "sre"}, want: true},
+		{name: "no match", user: "bob", groups: []string{"dev"}},
+		{name: "group name is not a user", user: "sre"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesApprovers(approvers, tt.user, tt.groups); got != tt.want {
+				t.Errorf("MatchesApprovers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSelfApproval(t *testing.T) {
+	direct := accessv1alpha1.AccessRequestBaseSpec{Subject: "alice"}
+	delegated := accessv1alpha1.AccessRequestBaseSpec{Subject: "alice", Delegator: "carol"}
+
+	if !IsSelfApproval(direct, "alice") || IsSelfApproval(direct, "bob") || IsSelfApproval(direct, "") {
+		t.Errorf("unexpected result for a direct request")
+	}
+	if !IsSelfApproval(delegated, "alice") || !IsSelfApproval(delegated, "carol") || IsSelfApproval(delegated, "bob") {
+		t.Errorf("unexpected result for a delegated request")
+	}
+}
+
+func TestAllApprovers(t *testing.T) {
+	spec := &accessv1alpha1.SubjectPolicy{
+		Approvers: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		ApprovalStages: []accessv1alpha1.ApprovalStage{
+			{Name: "security", Approvers: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "security"}}, RequiredApprovals: 1},
+		},
+		ApprovalQuorum: []accessv1alpha1.GroupQuorum{{Group: "sre", RequiredApprovals: 1}},
+	}
+
+	approvers := AllApprovers(spec)
+	if len(approvers) != 3 {
+		t.Fatalf("expected 3 approvers, got %v", approvers)
+	}
+	if !MatchesApprovers(approvers, "alice", nil) || !MatchesApprovers(approvers, "bob", []string{"security"}) || !MatchesApprovers(approvers, "carol", []string{"sre"}) {
+		t.Errorf("expected approvers from every approval rule, got %v", approvers)
+	}
+	if MatchesApprovers(approvers, "dave", []string{"dev"}) {
+		t.Errorf("expected dave not to be an approver")
+	}
+	if len(spec.Approvers) != 1 {
+		t.Errorf("expected the policy's approvers to be left unchanged, got %v", spec.Approvers)
+	}
+}
+
+func TestEvaluateStages(t *testing.T) {
+	stages := []accessv1alpha1.ApprovalStage{
+		{
+			Name:              "team-lead",
+			Approvers:         []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "leads"}},
+			RequiredApprovals: 1,
+		},
+		{
+			Name:              "security",
+			Approvers:         []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "security"}, {Kind: rbacv1.UserKind, Name: "carol"}},
+			RequiredApprovals: 2,
+		},
+	}
+
+	now := time.Now()
+	approval := func(approver string, minutes int, groups ...string) Approval {
+		return Approval{Approver: approver, Groups: groups, ApprovedAt: metav1.NewTime(now.Add(time.Duration(minutes) * time.Minute))}
+	}
+
+	tests := []struct {
+		name         string
+		approvals    []Approval
+		wantCurrent  string
+		wantComplete bool
+		wantApproved [][]string
+	}{
+		{
+			name:         "no approvals",
+			wantCurrent:  "team-lead",
+			wantApproved: [][]string{nil, nil},
+		},
+		{
+			name:         "first stage complete",
+			approvals:    []Approval{approval("alice", 0, "leads")},
+			wantCurrent:  "security",
+			wantApproved: [][]string{{"alice"}, nil},
+		},
+		{
+			name:         "later stage can't progress before earlier stages",
+			approvals:    []Approval{approval("bob", 0, "security"), approval("carol", 1)},
+			wantCurrent:  "team-lead",
+			wantApproved: [][]string{nil, nil},
+		},
+		{
+			name: "approver only counts towards one stage",
+			approvals: []Approval{
+				approval("alice", 0, "leads", "security"),
+				approval("bob", 1, "security"),
+			},
+			wantCurrent:  "security",
+			wantApproved: [][]string{{"alice"}, {"bob"}},
+		},
+		{
+			name: "all stages complete",
+			approvals: []Approval{
+				approval("carol", 2),
+				approval("bob", 1, "security"),
+				approval("alice", 0, "leads"),
+			},
+			wantComplete: true,
+			wantApproved: [][]string{{"alice"}, {"bob", "carol"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses, current, complete := EvaluateStages(stages, tt.approvals)
+
+			if current != tt.wantCurrent || complete != tt.wantComplete {
+				t.Fatalf("EvaluateStages() = %q, %v, want %q, %v", current, complete, tt.wantCurrent, tt.wantComplete)
+			}
+			for i, status := range statuses {
+				if !slices.Equal(status.ApprovedBy, tt.wantApproved[i]) {
+					t.Errorf("stage %s approved by %v, want %v", status.Name, status.ApprovedBy, tt.wantApproved[i])
+				}
+				if (status.ApprovalsReceived >= status.ApprovalsRequired) == status.CompletedAt.IsZero() {
+					t.Errorf("stage %s has an unexpected completion time %v", status.Name, status.CompletedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestCurrentApprovers(t *testing.T) {
+	spec := &accessv1alpha1.SubjectPolicy{
+		Approvers: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+	}
+
+	approvers, stage := CurrentApprovers(spec, &accessv1alpha1.AccessRequestStatus{})
+	if stage != "" || approvers[0].Name != "alice" {
+		t.Fatalf("expected the policy approvers, got %v in stage %q", approvers, stage)
+	}
+
+	spec.ApprovalQuorum = []accessv1alpha1.GroupQuorum{{Group: "security", RequiredApprovals: 1}}
+	approvers, _ = CurrentApprovers(spec, &accessv1alpha1.AccessRequestStatus{})
+	if !MatchesApprovers(approvers, "bob", []string{"security"}) {
+		t.Fatalf("expected quorum groups to be approvers, got %v", approvers)
+	}
+
+	spec.ApprovalStages = []accessv1alpha1.ApprovalStage{
+		{Name: "first", Approvers: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}}},
+		{Name: "second", Approvers: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "carol"}}},
+	}
+
+	approvers, stage = CurrentApprovers(spec, &accessv1alpha1.AccessRequestStatus{})
+	if stage != "first" || approvers[0].Name != "bob" {
+		t.Fatalf("expected the first stage, got %v in stage %q", approvers, stage)
+	}
+
+	approvers, stage = CurrentApprovers(spec, &accessv1alpha1.AccessRequestStatus{CurrentStage: "second"})
+	if stage != "second" || approvers[0].Name != "carol" {
+		t.Fatalf("expected the second stage, got %v in stage %q", approvers, stage)
+	}
+}
+
+func TestEvaluateQuorum(t *testing.T) {
+	quorum := []accessv1alpha1.GroupQuorum{
+		{Group: "sre", RequiredApprovals: 1},
+		{Group: "security", RequiredApprovals: 1},
+	}
+
+	now := time.Now()
+	approval := func(approver string, minutes int, groups ...string) Approval {
+		return Approval{Approver: approver, Groups: groups, ApprovedAt: metav1.NewTime(now.Add(time.Duration(minutes) * time.Minute))}
+	}
+
+	tests := []struct {
+		name         string
+		approvals    []Approval
+		wantAssigned map[string]string
+		wantUnmet    []string
+	}{
+		{
+			name:         "no approvals",
+			wantAssigned: map[string]string{},
+			wantUnmet:    []string{"sre", "security"},
+		},
+		{
+			name:         "two approvers from the same group",
+			approvals:    []Approval{approval("alice", 0, "sre"), approval("bob", 1, "sre")},
+			wantAssigned: map[string]string{"alice": "sre"},
+			wantUnmet:    []string{"security"},
+		},
+		{
+			name:         "an approver in both groups only counts once",
+			approvals:    []Approval{approval("alice", 0, "sre", "security")},
+			wantAssigned: map[string]string{"alice": "sre"},
+			wantUnmet:    []string{"security"},
+		},
+		{
+			name:         "earlier approvals move to free a group",
+			approvals:    []Approval{approval("alice", 0, "sre", "security"), approval("bob", 1, "sre")},
+			wantAssigned: map[string]string{"alice": "security", "bob": "sre"},
+			wantUnmet:    []string{},
+		},
+		{
+			name:         "approvals from outside the quorum are ignored",
+			approvals:    []Approval{approval("alice", 0, "sre"), approval("carol", 1, "dev"), approval("dave", 2, "security")},
+			wantAssigned: map[string]string{"alice": "sre", "dave": "security"},
+			wantUnmet:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigned, unmet := EvaluateQuorum(quorum, tt.approvals)
+
+			if !maps.Equal(assigned, tt.wantAssigned) {
+				t.Errorf("assigned = %v, want %v", assigned, tt.wantAssigned)
+			}
+			if !slices.Equal(unmet, tt.wantUnmet) {
+				t.Errorf("unmet = %v, want %v", unmet, tt.wantUnmet)
+			}
+		})
+	}
+}
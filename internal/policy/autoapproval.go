@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AutoApproverPrefix prefixes the approver recorded for auto-approvals
+const AutoApproverPrefix = "auto-approval:"
+
+// OnCallLookup finds the roster entry that has a subject on call
+type OnCallLookup interface {
+	Lookup(
+		ctx context.Context,
+		requirement *accessv1alpha1.OnCallRequirement,
+		subject string,
+		groups []string,
+		now time.Time,
+	) (*accessv1alpha1.OnCallEntry, error)
+}
+
+// AutoApprove returns the first auto-approval rule that matches the request, or
+// nil when the request needs to be approved by the policy's approvers. For rules
+// with an on-call requirement the roster entry that matched is returned as well.
+func (r *PolicyResolver) AutoApprove(
+	ctx context.Context,
+	req common.AccessRequestObject,
+	rules []accessv1alpha1.AutoApprovalRule,
+	onCall OnCallLookup,
+) (*accessv1alpha1.AutoApprovalRule, *accessv1alpha1.OnCallEntry, error) {
+	log := logf.FromContext(ctx)
+	rc := &resolveContext{ctx: ctx, client: r.Client, req: req}
+	spec := req.GetSpec()
+
+	for i := range rules {
+		rule := &rules[i]
+		matched, err := matchesAutoApproval(rc, rule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to evaluate auto-approval rule %s: %w", rule.Name, err)
+		}
+		if !matched {
+			continue
+		}
+		if rule.OnCall == nil {
+			return rule, nil, nil
+		}
+
+		// Rosters that can't be looked up don't match, the request falls back to
+		// the policy's approvers
+		entry, err := onCall.Lookup(ctx, rule.OnCall, spec.Subject, spec.Groups, time.Now())
+		if err != nil {
+			log.Error(err, "unable to look up on-call roster for auto-approval rule", "rule", rule.Name, "roster", rule.OnCall.Roster)
+			continue
+		}
+		if entry != nil {
+			return rule, entry, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// AutoApproval returns the synthetic approval recorded for a request approved by
+// the rule. The on-call entry is recorded when it justified the approval.
+func AutoApproval(rule *accessv1alpha1.AutoApprovalRule, onCall *accessv1alpha1.OnCallEntry, at metav1.Time) accessv1alpha1.AccessRequestApproval {
+	reason := rule.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("matched auto-approval rule %s", rule.Name)
+		if onCall != nil {
+			reason = fmt.Sprintf("%s on call in roster %s until %s", onCall.User, onCall.Roster, onCall.End.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return accessv1alpha1.AccessRequestApproval{
+		Approver:   AutoApproverPrefix + rule.Name,
+		ApprovedAt: at,
+		Reason:     reason,
+		OnCall:     onCall,
+	}
+}
+
+func matchesAutoApproval(rc *resolveContext, rule *accessv1alpha1.AutoApprovalRule) (bool, error) {
+	spec := rc.req.GetSpec()
+
+	if len(rule.Verbs) > 0 {
+		if spec.Role.Name != "" || len(spec.Permissions) == 0 {
+			return false, nil
+		}
+		for _, permission := range spec.Permissions {
+			if !fieldAllows(permission.Verbs, rule.Verbs) {
+				return false, nil
+			}
+		}
+	}
+
+	if rule.MaxDuration != "" && !matchesDuration(rule.MaxDuration, spec.Duration) {
+		return false, nil
+	}
+
+	if rule.NamespaceSelector != nil {
+		if rc.req.GetScope() != accessv1alpha1.RequestScopeNamespace {
+			return false, nil
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(rule.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+
+		nsLabels, err := rc.namespaceLabels()
+		if err != nil {
+			return false, err
+		}
+
+		if !selector.Matches(labels.Set(nsLabels)) {
+			return false, nil
+		}
+	}
+
+	if len(rule.Conditions) > 0 {
+		vars, err := conditionVariables(rc)
+		if err != nil {
+			return false, err
+		}
+
+		// Conditions that fail to evaluate don't match, the request falls back to
+		// the policy's approvers
+		for _, condition := range rule.Conditions {
+			if ok, err := evaluateCondition(condition.Expression, vars); err != nil || !ok {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/oncall"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAutoApprove(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	devNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}}
+	prodNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+
+	resolver := &PolicyResolver{
+		Client: ctrlclient.NewClientBuilder().WithScheme(sch).WithObjects(devNs, prodNs).Build(),
+	}
+
+	rules := []accessv1alpha1.AutoApprovalRule{
+		{
+			Name:        "read-only",
+			Verbs:       []string{"get", "list", "watch"},
+			MaxDuration: "15m",
+		},
+		{
+			Name:              "dev",
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			Conditions:        []accessv1alpha1.PolicyCondition{{Expression: `"oncall" in groups`}},
+		},
+	}
+
+	readRule := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}}
+	writeRule := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "delete"}}}
+	editRole := rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"}
+
+	request := func(namespace, duration string, permissions []rbacv1.PolicyRule, role rbacv1.RoleRef, groups ...string) common.AccessRequestObject {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: namespace},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:     "alice",
+					Groups:      groups,
+					Duration:    duration,
+					Permissions: permissions,
+					Role:        role,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		req      common.AccessRequestObject
+		wantRule string
+	}{
+		{name: "short read-only request", req: request("prod", "10m", readRule, rbacv1.RoleRef{}), wantRule: "read-only"},
+		{name: "read-only request that is too long", req: request("prod", "1h", readRule, rbacv1.RoleRef{})},
+		{name: "request with write verbs", req: request("prod", "10m", writeRule, rbacv1.RoleRef{})},
+		{name: "request for a role doesn't match verbs", req: request("prod", "10m", nil, editRole)},
+		{name: "on-call request in a dev namespace", req: request("dev", "1h", nil, editRole, "oncall"), wantRule: "dev"},
+		{name: "on-call request in a prod namespace", req: request("prod", "1h", nil, editRole, "oncall")},
+		{name: "request in a dev namespace outside on-call", req: request("dev", "1h", nil, editRole)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, _, err := resolver.AutoApprove(context.Background(), tt.req, rules, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ""
+			if rule != nil {
+				got = rule.Name
+			}
+			if got != tt.wantRule {
+				t.Errorf("AutoApprove() = %q, want %q", got, tt.wantRule)
+			}
+		})
+	}
+}
+
+type stubOnCall map[string]*accessv1alpha1.OnCallEntry
+
+func (s stubOnCall) Lookup(
+	_ context.Context,
+	requirement *accessv1alpha1.OnCallRequirement,
+	subject string,
+	_ []string,
+	_ time.Time,
+) (*accessv1alpha1.OnCallEntry, error) {
+	if requirement.Roster == "broken" {
+		return nil, errors.New("roster unavailable")
+	}
+	return s[requirement.Roster+"/"+subject], nil
+}
+
+func TestAutoApproveOnCall(t *testing.T) {
+	resolver := &PolicyResolver{}
+	entry := &accessv1alpha1.OnCallEntry{Roster: "payments-primary", User: "alice"}
+	onCall := stubOnCall{"payments-primary/alice": entry}
+
+	rules := []accessv1alpha1.AutoApprovalRule{
+		{Name: "broken-roster", OnCall: &accessv1alpha1.OnCallRequirement{Roster: "broken"}},
+		{Name: "on-call", MaxDuration: "4h", OnCall: &accessv1alpha1.OnCallRequirement{Roster: "payments-primary"}},
+	}
+
+	request := func(subject, duration string) common.AccessRequestObject {
+		return &accessv1alpha1.ClusterAccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req"},
+			Spec: accessv1alpha1.ClusterAccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:  subject,
+					Duration: duration,
+					Role:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		req       common.AccessRequestObject
+		onCall    OnCallLookup
+		wantRule  string
+		wantEntry *accessv1alpha1.OnCallEntry
+	}{
+		{name: "subject on call", req: request("alice", "1h"), onCall: onCall, wantRule: "on-call", wantEntry: entry},
+		{name: "subject off call", req: request("bob", "1h"), onCall: onCall},
+		{name: "on-call request that is too long", req: request("alice", "8h"), onCall: onCall},
+		{name: "no on-call source", req: request("alice", "1h"), onCall: (*oncall.Resolver)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, got, err := resolver.AutoApprove(context.Background(), tt.req, rules, tt.onCall)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			gotRule := ""
+			if rule != nil {
+				gotRule = rule.Name
+			}
+			if gotRule != tt.wantRule {
+				t.Errorf("AutoApprove() rule = %q, want %q", gotRule, tt.wantRule)
+			}
+			if got != tt.wantEntry {
+				t.Errorf("AutoApprove() entry = %+v, want %+v", got, tt.wantEntry)
+			}
+		})
+	}
+}
+
+func TestAutoApproval(t *testing.T) {
+	now := metav1.Now()
+
+	approval := AutoApproval(&accessv1alpha1.AutoApprovalRule{Name: "read-only"}, nil, now)
+	if approval.Approver != "auto-approval:read-only" || approval.Reason != "matched auto-approval rule read-only" {
+		t.Errorf("unexpected approval: %+v", approval)
+	}
+
+	approval = AutoApproval(&accessv1alpha1.AutoApprovalRule{Name: "read-only", Reason: "read access is pre-approved"}, nil, now)
+	if approval.Reason != "read access is pre-approved" {
+		t.Errorf("unexpected reason: %q", approval.Reason)
+	}
+
+	entry := &accessv1alpha1.OnCallEntry{
+		Roster: "payments-primary",
+		User:   "alice",
+		End:    metav1.NewTime(time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)),
+	}
+	approval = AutoApproval(&accessv1alpha1.AutoApprovalRule{Name: "on-call"}, entry, now)
+	if approval.OnCall != entry || approval.Reason != "alice on call in roster payments-primary until 2026-10-17T20:00:00Z" {
+		t.Errorf("unexpected approval: %+v", approval)
+	}
+}
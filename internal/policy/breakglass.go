@@ -0,0 +1,47 @@
+package policy
+
+import (
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// BreakGlassReviewers returns the users and groups allowed to review the
+// policy's break-glass requests, which default to all of its approvers
+func BreakGlassReviewers(spec *accessv1alpha1.SubjectPolicy) []rbacv1.Subject {
+	if spec.BreakGlass != nil && len(spec.BreakGlass.Reviewers) > 0 {
+		return spec.BreakGlass.Reviewers
+	}
+	return AllApprovers(spec)
+}
+
+// ResolvedPolicy returns the policy recorded in the request's status as the
+// one it matched, or nil when it no longer exists
+func ResolvedPolicy(req common.AccessRequestObject, policies []common.AccessPolicyObject) common.AccessPolicyObject {
+	status := req.GetStatus()
+	for _, p := range policies {
+		if p.GetName() != status.ResolvedPolicy || p.GetScope() != status.ResolvedPolicyScope {
+			continue
+		}
+		if p.GetScope() != "Cluster" && p.GetNamespace() != req.GetNamespace() {
+			continue
+		}
+		return p
+	}
+	return nil
+}
+
+// EarliestReview returns the first review of a break-glass request that wasn't
+// made by its subject or delegator, or nil when there's none
+func EarliestReview(spec accessv1alpha1.AccessRequestBaseSpec, reviews []common.BreakGlassReviewObject) common.BreakGlassReviewObject {
+	var earliest common.BreakGlassReviewObject
+	for _, review := range reviews {
+		if IsSelfApproval(spec, review.GetReviewer()) {
+			continue
+		}
+		if earliest == nil || review.GetCreationTimestamp().Time.Before(earliest.GetCreationTimestamp().Time) {
+			earliest = review
+		}
+	}
+	return earliest
+}
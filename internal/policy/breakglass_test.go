@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBreakGlassReviewers(t *testing.T) {
+	approvers := []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}}
+	reviewers := []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "security"}}
+
+	tests := []struct {
+		name string
+		spec *accessv1alpha1.SubjectPolicy
+		want string
+	}{
+		{
+			name: "reviewers set",
+			spec: &accessv1alpha1.SubjectPolicy{Approvers: approvers, BreakGlass: &accessv1alpha1.BreakGlassPolicy{Reviewers: reviewers}},
+			want: "security",
+		},
+		{
+			name: "defaults to the approvers",
+			spec: &accessv1alpha1.SubjectPolicy{Approvers: approvers, BreakGlass: &accessv1alpha1.BreakGlassPolicy{}},
+			want: "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BreakGlassReviewers(tt.spec)
+			if len(got) != 1 || got[0].Name != tt.want {
+				t.Errorf("BreakGlassReviewers() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvedPolicy(t *testing.T) {
+	policies := []common.AccessPolicyObject{
+		&accessv1alpha1.AccessPolicy{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "team-b"}},
+		&accessv1alpha1.AccessPolicy{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "team-a"}},
+		&accessv1alpha1.ClusterAccessPolicy{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+	}
+
+	request := func(scope accessv1alpha1.PolicyScope) common.AccessRequestObject {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a"},
+			Status:     accessv1alpha1.AccessRequestStatus{ResolvedPolicy: "shared", ResolvedPolicyScope: scope},
+		}
+	}
+
+	if got := ResolvedPolicy(request("Namespace"), policies); got == nil || got.GetNamespace() != "team-a" {
+		t.Errorf("ResolvedPolicy() = %v, want the policy in the request's namespace", got)
+	}
+	if got := ResolvedPolicy(request("Cluster"), policies); got == nil || got.GetScope() != "Cluster" {
+		t.Errorf("ResolvedPolicy() = %v, want the cluster policy", got)
+	}
+	if got := ResolvedPolicy(request("Cluster"), policies[:2]); got != nil {
+		t.Errorf("ResolvedPolicy() = %v, want nil", got)
+	}
+}
+
+func TestEarliestReview(t *testing.T) {
+	now := time.Now()
+	review := func(name, reviewer string, age time.Duration) common.BreakGlassReviewObject {
+		return &accessv1alpha1.BreakGlassReview{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec:       accessv1alpha1.BreakGlassReviewSpec{Reviewer: reviewer, Outcome: accessv1alpha1.ReviewOutcomeAcceptable},
+		}
+	}
+	spec := accessv1alpha1.AccessRequestBaseSpec{Subject: "alice", Delegator: "carol"}
+
+	tests := []struct {
+		name    string
+		reviews []common.BreakGlassReviewObject
+		want    string
+	}{
+		{name: "no reviews"},
+		{
+			name:    "earliest wins",
+			reviews: []common.BreakGlassReviewObject{review("late", "bob", time.Minute), review("early", "dave", time.Hour)},
+			want:    "early",
+		},
+		{
+			name:    "subject and delegator are ignored",
+			reviews: []common.BreakGlassReviewObject{review("self", "alice", time.Hour), review("delegator", "carol", time.Hour), review("peer", "bob", time.Minute)},
+			want:    "peer",
+		},
+		{
+			name:    "only self reviews",
+			reviews: []common.BreakGlassReviewObject{review("self", "alice", time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EarliestReview(spec, tt.reviews)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("EarliestReview() = %s, want nil", got.GetName())
+				}
+				return
+			}
+			if got == nil || got.GetName() != tt.want {
+				t.Errorf("EarliestReview() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// conditionCostLimit bounds the work a single condition can do per evaluation
+const conditionCostLimit = 1_000_000
+
+var (
+	conditionEnvOnce sync.Once
+	conditionEnv     *cel.Env
+	conditionEnvErr  error
+
+	// compiled programs keyed by expression
+	conditionPrograms sync.Map
+)
+
+func conditionEnvironment() (*cel.Env, error) {
+	conditionEnvOnce.Do(func() {
+		conditionEnv, conditionEnvErr = cel.NewEnv(
+			cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable("groups", cel.ListType(cel.StringType)),
+			cel.Variable("namespaceObject", cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable("now", cel.TimestampType),
+		)
+	})
+	return conditionEnv, conditionEnvErr
+}
+
+// CompileCondition parses and type-checks a condition expression.
+func CompileCondition(expression string) (cel.Program, error) {
+	if prg, ok := conditionPrograms.Load(expression); ok {
+		return prg.(cel.Program), nil
+	}
+
+	env, err := conditionEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", ast.OutputType())
+	}
+
+	prg, err := env.Program(ast, cel.CostLimit(conditionCostLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	conditionPrograms.Store(expression, prg)
+	return prg, nil
+}
+
+// evaluateConditions returns the message of the first condition that isn't
+// satisfied, or an empty string when they all evaluate to true.
+func evaluateConditions(rc *resolveContext, conditions []accessv1alpha1.PolicyCondition) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	vars, err := conditionVariables(rc)
+	if err != nil {
+		return fmt.Sprintf("unable to evaluate conditions: %s", err)
+	}
+
+	for _, condition := range conditions {
+		ok, err := evaluateCondition(condition.Expression, vars)
+		if err != nil {
+			return fmt.Sprintf("condition %q failed: %s", condition.Expression, err)
+		}
+		if !ok {
+			if condition.Message != "" {
+				return condition.Message
+			}
+			return fmt.Sprintf("condition %q is not satisfied", condition.Expression)
+		}
+	}
+
+	return ""
+}
+
+func evaluateCondition(expression string, vars map[string]any) (bool, error) {
+	prg, err := CompileCondition(expression)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to a bool, got %s", out.Type())
+	}
+
+	return result, nil
+}
+
+func conditionVariables(rc *resolveContext) (map[string]any, error) {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rc.req.GetSpec())
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert request spec: %w", err)
+	}
+
+	nsLabels, err := rc.namespaceLabels()
+	if err != nil {
+		return nil, err
+	}
+	if nsLabels == nil {
+		nsLabels = map[string]string{}
+	}
+
+	groups := rc.req.GetSpec().Groups
+	if groups == nil {
+		groups = []string{}
+	}
+
+	return map[string]any{
+		"request": spec,
+		"groups":  groups,
+		"namespaceObject": map[string]any{
+			"metadata": map[string]any{
+				"name":   rc.req.GetNamespace(),
+				"labels": nsLabels,
+			},
+		},
+		"now": time.Now(),
+	}, nil
+}
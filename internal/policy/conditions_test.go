@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveConditions(t *testing.T) {
+	ctx := context.Background()
+
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	fakeClient := ctrlclient.NewClientBuilder().WithScheme(sch).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}},
+		).
+		Build()
+
+	resolver := &PolicyResolver{Client: fakeClient}
+
+	policyIn := func(namespace string, conditions ...accessv1alpha1.PolicyCondition) *accessv1alpha1.AccessPolicy {
+		return &accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "conditional", Namespace: namespace},
+			Spec: accessv1alpha1.AccessPolicySpec{
+				SubjectPolicy: accessv1alpha1.SubjectPolicy{
+					MaxDuration:  "1h",
+					AllowedRoles: []rbacv1.RoleRef{{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "edit"}},
+					Conditions:   conditions,
+				},
+			},
+		}
+	}
+
+	request := func(namespace, duration string, groups ...string) *accessv1alpha1.AccessRequest {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: namespace},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:  "alice",
+					Groups:   groups,
+					Duration: duration,
+					Role:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "edit"},
+				},
+			},
+		}
+	}
+
+	nonProdOrShort := accessv1alpha1.PolicyCondition{
+		Expression: `namespaceObject.metadata.labels["env"] != "prod" || duration(request.duration) <= duration("15m")`,
+		Message:    "production access is limited to 15 minutes",
+	}
+
+	tests := []struct {
+		name       string
+		policy     *accessv1alpha1.AccessPolicy
+		req        *accessv1alpha1.AccessRequest
+		wantMatch  bool
+		wantReason string
+	}{
+		{
+			name:      "non-production namespace",
+			policy:    policyIn("dev", nonProdOrShort),
+			req:       request("dev", "30m"),
+			wantMatch: true,
+		},
+		{
+			name:      "short production request",
+			policy:    policyIn("prod", nonProdOrShort),
+			req:       request("prod", "10m"),
+			wantMatch: true,
+		},
+		{
+			name:       "long production request returns the condition message",
+			policy:     policyIn("prod", nonProdOrShort),
+			req:        request("prod", "30m"),
+			wantReason: "production access is limited to 15 minutes",
+		},
+		{
+			name: "groups are available",
+			policy: policyIn("dev", accessv1alpha1.PolicyCondition{
+				Expression: `"sre" in groups`,
+			}),
+			req:        request("dev", "10m", "developers"),
+			wantReason: `condition "\"sre\" in groups" is not satisfied`,
+		},
+		{
+			name: "now is available",
+			policy: policyIn("dev", accessv1alpha1.PolicyCondition{
+				Expression: `now > timestamp("2000-01-01T00:00:00Z")`,
+			}),
+			req:       request("dev", "10m"),
+			wantMatch: true,
+		},
+		{
+			name: "non-bool expressions are rejected",
+			policy: policyIn("dev", accessv1alpha1.PolicyCondition{
+				Expression: `request.duration`,
+			}),
+			req:        request("dev", "10m"),
+			wantReason: "must evaluate to a bool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, trace := resolver.ResolveWithTrace(ctx, tt.req, []common.AccessPolicyObject{tt.policy})
+			reasons := trace.Reasons()
+			if (matched != nil) != tt.wantMatch {
+				t.Fatalf("got match %v, want %v (reasons: %v)", matched != nil, tt.wantMatch, reasons)
+			}
+			if tt.wantReason != "" && (len(reasons) != 1 || !strings.Contains(reasons[0], tt.wantReason)) {
+				t.Errorf("expected a reason containing %q, got %v", tt.wantReason, reasons)
+			}
+		})
+	}
+}
+
+func TestCompileCondition(t *testing.T) {
+	if _, err := CompileCondition(`request.subject == "alice"`); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := CompileCondition(`request.subject ==`); err == nil {
+		t.Errorf("expected a syntax error")
+	}
+	if _, err := CompileCondition(`1 + 1`); err == nil {
+		t.Errorf("expected an error for a non-bool expression")
+	}
+}
@@ -0,0 +1,176 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearch bounds how far ahead the next occurrence of a schedule is
+// looked for, so expressions that never match (e.g. "0 0 30 2 *") terminate
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// Cron is a parsed five field cron expression (minute, hour, day of month,
+// month and day of week) evaluated in a time zone
+type Cron struct {
+	minutes  []bool
+	hours    []bool
+	days     []bool
+	months   []bool
+	weekdays []bool
+	// Like cron, a day matches either field when both day fields are restricted
+	anyDay     bool
+	anyWeekday bool
+	loc        *time.Location
+}
+
+var weekdayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+// ParseCron parses a cron expression such as "0 9 * * MON-FRI" in the given
+// time zone, which defaults to UTC
+func ParseCron(expr, timeZone string) (*Cron, error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q, expected 5 fields (minute hour day-of-month month day-of-week)", expr)
+	}
+
+	c := &Cron{loc: loc, anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	if c.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute: %w", err)
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour: %w", err)
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month: %w", err)
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month: %w", err)
+	}
+	// 7 is accepted as Sunday
+	if c.weekdays, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week: %w", err)
+	}
+	if c.weekdays[7] {
+		c.weekdays[0] = true
+	}
+
+	return c, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges ("1-5"),
+// wildcards and steps ("*/15", "9-17/2") into the set of values it matches
+func parseCronField(field string, minValue, maxValue int, names map[string]int) ([]bool, error) {
+	values := make([]bool, maxValue+1)
+
+	parseValue := func(s string) (int, error) {
+		if v, ok := names[strings.ToUpper(s)]; ok {
+			return v, nil
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v < minValue || v > maxValue {
+			return 0, fmt.Errorf("%q is not between %d and %d", s, minValue, maxValue)
+		}
+		return v, nil
+	}
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(first); err != nil {
+				return nil, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseValue(last); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				end = maxValue
+			}
+			if start > end {
+				return nil, fmt.Errorf("range %q must start before it ends", rangePart)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// Next returns the first occurrence of the schedule after t, or a zero time
+// when the schedule never occurs
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+
+	for t.Before(limit) {
+		switch {
+		case !c.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+		case !c.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+		case !c.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	day, weekday := c.days[t.Day()], c.weekdays[t.Weekday()]
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// ActiveOccurrence returns the start of the latest occurrence that started at
+// or before now and lasts past now, when there is one
+func (c *Cron) ActiveOccurrence(duration time.Duration, now time.Time) (time.Time, bool) {
+	var active time.Time
+	for start := c.Next(now.Add(-duration)); !start.IsZero() && !start.After(now); start = c.Next(start) {
+		active = start
+	}
+	return active, !active.IsZero()
+}
@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr     string
+		timeZone string
+		wantErr  bool
+	}{
+		{expr: "0 9 * * MON"},
+		{expr: "*/15 9-17 * * 1-5"},
+		{expr: "30 22 1,15 * *"},
+		{expr: "0 0 * JAN-MAR sun", timeZone: "Europe/London"},
+		{expr: "0 9 * *", wantErr: true},
+		{expr: "60 9 * * *", wantErr: true},
+		{expr: "0 17-9 * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "0 9 * * MON", timeZone: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr, tt.timeZone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("unable to load time zone: %v", err)
+	}
+
+	// Thursday
+	from := time.Date(2025, 6, 12, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		want     time.Time
+	}{
+		{name: "every monday morning", expr: "0 9 * * MON", want: time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC)},
+		{name: "next step", expr: "*/15 * * * *", want: time.Date(2025, 6, 12, 10, 15, 0, 0, time.UTC)},
+		{name: "later the same day", expr: "0 22 * * *", want: time.Date(2025, 6, 12, 22, 0, 0, 0, time.UTC)},
+		{name: "next month", expr: "0 0 1 * *", want: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or weekday", expr: "0 0 20 * SAT", want: time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 0 * * 7", want: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "time zone", expr: "0 9 * * MON", timeZone: "Europe/London", want: time.Date(2025, 6, 16, 9, 0, 0, 0, london)},
+		{name: "never", expr: "0 0 30 2 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr, tt.timeZone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := c.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronActiveOccurrence(t *testing.T) {
+	c, err := ParseCron("0 9 * * MON", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	monday := time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		duration   time.Duration
+		now        time.Time
+		wantActive bool
+	}{
+		{name: "at the start", duration: 8 * time.Hour, now: monday, wantActive: true},
+		{name: "during the occurrence", duration: 168 * time.Hour, now: monday.Add(100 * time.Hour), wantActive: true},
+		{name: "after the occurrence", duration: 8 * time.Hour, now: monday.Add(8 * time.Hour)},
+		{name: "before the occurrence", duration: 8 * time.Hour, now: monday.Add(-time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, active := c.ActiveOccurrence(tt.duration, tt.now)
+			if active != tt.wantActive {
+				t.Fatalf("ActiveOccurrence() active = %v, want %v", active, tt.wantActive)
+			}
+			if active && !start.Equal(monday) {
+				t.Errorf("ActiveOccurrence() = %v, want %v", start, monday)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// checkDenied names the first requested rule or role the policy denies, or
+// returns an empty string when nothing is denied. Deny rules take precedence
+// over the policy's allow lists.
+func checkDenied(
+	deniedRoles []rbacv1.RoleRef,
+	deniedRules []rbacv1.PolicyRule,
+	requestedRole rbacv1.RoleRef,
+	requestedRules []rbacv1.PolicyRule,
+) string {
+	if requestedRole.Name != "" {
+		for _, ref := range deniedRoles {
+			if roleRefEquals(ref, requestedRole) {
+				return fmt.Sprintf("role %s %s is denied", requestedRole.Kind, requestedRole.Name)
+			}
+		}
+	}
+
+	for i, rule := range requestedRules {
+		for j, denied := range deniedRules {
+			if rulesOverlap(rule, denied) {
+				return fmt.Sprintf("permission rule %d (%s) is denied by deniedPermissions[%d]", i, describeRule(rule), j)
+			}
+		}
+	}
+
+	return ""
+}
+
+// checkDeniedRoleRules names the first rule of the requested role the policy
+// denies, or returns an empty string when none are. A role whose rules can't
+// be looked up is denied, since it may grant anything.
+func checkDeniedRoleRules(rc *resolveContext, deniedRules []rbacv1.PolicyRule) string {
+	role := rc.req.GetSpec().Role
+	if role.Name == "" || len(deniedRules) == 0 {
+		return ""
+	}
+
+	rules, err := rc.requestedRoleRules()
+	if err != nil {
+		return fmt.Sprintf("the rules of role %s %s can't be checked against deniedPermissions: %s", role.Kind, role.Name, err)
+	}
+
+	for i, rule := range rules {
+		for j, denied := range deniedRules {
+			if rulesOverlap(rule, denied) {
+				return fmt.Sprintf("role %s %s rule %d (%s) is denied by deniedPermissions[%d]", role.Kind, role.Name, i, describeRule(rule), j)
+			}
+		}
+	}
+
+	return ""
+}
+
+// rulesOverlap returns true if some request allowed by the requested rule is
+// also covered by the denied rule. A requested wildcard overlaps every value,
+// so requesting "*" resources is denied when any resource is.
+func rulesOverlap(requested, denied rbacv1.PolicyRule) bool {
+	if !fieldsOverlap(requested.Verbs, denied.Verbs) {
+		return false
+	}
+
+	if len(requested.NonResourceURLs) > 0 || len(denied.NonResourceURLs) > 0 {
+		return fieldsOverlap(requested.NonResourceURLs, denied.NonResourceURLs)
+	}
+
+	return fieldsOverlap(requested.APIGroups, denied.APIGroups) &&
+		fieldsOverlap(requested.Resources, denied.Resources) &&
+		resourceNamesOverlap(requested.ResourceNames, denied.ResourceNames)
+}
+
+// fieldsOverlap returns true if the fields share a value, either is a wildcard,
+// or a value of one matches a glob pattern of the other
+func fieldsOverlap(a, b []string) bool {
+	if hasWildcard(a) && len(b) > 0 || hasWildcard(b) && len(a) > 0 {
+		return true
+	}
+	for _, value := range a {
+		for _, other := range b {
+			if globMatch(value, other) || globMatch(other, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourceNamesOverlap is like fieldsOverlap, except an empty list stands for
+// every resource name
+func resourceNamesOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	return fieldsOverlap(a, b)
+}
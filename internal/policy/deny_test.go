@@ -0,0 +1,174 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRulesOverlap(t *testing.T) {
+	rule := func(groups, resources, names, verbs []string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{APIGroups: groups, Resources: resources, ResourceNames: names, Verbs: verbs}
+	}
+	core := []string{""}
+	all := []string{"*"}
+
+	tests := []struct {
+		name      string
+		requested rbacv1.PolicyRule
+		denied    rbacv1.PolicyRule
+		want      bool
+	}{
+		{
+			name:      "same resource",
+			requested: rule(core, []string{"secrets"}, nil, []string{"get"}),
+			denied:    rule(core, []string{"secrets"}, nil, all),
+			want:      true,
+		},
+		{
+			name:      "different resource",
+			requested: rule(core, []string{"pods"}, nil, []string{"get"}),
+			denied:    rule(core, []string{"secrets"}, nil, all),
+		},
+		{
+			name:      "requested wildcard resources",
+			requested: rule(core, all, nil, []string{"get"}),
+			denied:    rule(core, []string{"secrets"}, nil, all),
+			want:      true,
+		},
+		{
+			name:      "different verbs",
+			requested: rule(core, []string{"pods"}, nil, []string{"get"}),
+			denied:    rule(core, []string{"pods"}, nil, []string{"delete"}),
+		},
+		{
+			name:      "subresource isn't the resource",
+			requested: rule(core, []string{"pods"}, nil, []string{"create"}),
+			denied:    rule(core, []string{"pods/exec"}, nil, all),
+		},
+		{
+			name:      "different resource names",
+			requested: rule(core, []string{"secrets"}, []string{"app"}, []string{"get"}),
+			denied:    rule(core, []string{"secrets"}, []string{"tls"}, all),
+		},
+		{
+			name:      "all resource names",
+			requested: rule(core, []string{"secrets"}, nil, []string{"get"}),
+			denied:    rule(core, []string{"secrets"}, []string{"tls"}, all),
+			want:      true,
+		},
+		{
+			name:      "different API group",
+			requested: rule([]string{"apps"}, []string{"deployments"}, nil, []string{"get"}),
+			denied:    rule(core, all, nil, all),
+		},
+		{
+			name:      "resources don't overlap non-resource URLs",
+			requested: rbacv1.PolicyRule{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+			denied:    rule(all, all, nil, all),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rulesOverlap(tt.requested, tt.denied); got != tt.want {
+				t.Errorf("rulesOverlap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDenied(t *testing.T) {
+	ctx := context.Background()
+
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	role := func(name string, resources ...string) *rbacv1.Role {
+		return &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: resources, Verbs: []string{"get", "list"}}},
+		}
+	}
+
+	fakeClient := ctrlclient.NewClientBuilder().WithScheme(sch).
+		WithObjects(role("view", "pods", "pods/log"), role("edit", "pods", "secrets"), role("admin", "*")).
+		Build()
+
+	resolver := &PolicyResolver{Client: fakeClient}
+
+	debug := &accessv1alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "team-a"},
+		Spec: accessv1alpha1.AccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{
+				Requesters:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+				MaxDuration:        "1h",
+				AllowedPermissions: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+				AllowedRoles:       []rbacv1.RoleRef{{Kind: "Role", Name: "view"}, {Kind: "Role", Name: "edit"}, {Kind: "Role", Name: "missing"}, {Kind: "Role", Name: "admin"}},
+				DeniedPermissions: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"secrets", "pods/exec"}, Verbs: []string{"*"}},
+				},
+				DeniedRoles: []rbacv1.RoleRef{{Kind: "Role", Name: "admin"}},
+			},
+		},
+	}
+
+	request := func(role string, resources ...string) common.AccessRequestObject {
+		req := &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{Subject: "alice", Duration: "30m"},
+			},
+		}
+		if role != "" {
+			req.Spec.Role = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: role}
+		}
+		if len(resources) > 0 {
+			req.Spec.Permissions = []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: resources, Verbs: []string{"get", "create"}}}
+		}
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		req        common.AccessRequestObject
+		wantReason string
+	}{
+		{name: "allowed resources", req: request("", "pods", "pods/log")},
+		{name: "denied resource", req: request("", "pods", "secrets"), wantReason: "is denied by deniedPermissions[0]"},
+		{name: "denied subresource", req: request("", "pods/exec"), wantReason: "is denied by deniedPermissions[0]"},
+		{name: "wildcard resources", req: request("", "*"), wantReason: "is denied by deniedPermissions[0]"},
+		{name: "allowed role", req: request("view")},
+		{name: "denied role", req: request("admin"), wantReason: "role Role admin is denied"},
+		{name: "role with a denied rule", req: request("edit"), wantReason: "role Role edit rule 0 (get,list on pods,secrets) is denied by deniedPermissions[0]"},
+		{name: "role that doesn't exist", req: request("missing"), wantReason: "can't be checked against deniedPermissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, trace := resolver.ResolveWithTrace(ctx, tt.req, []common.AccessPolicyObject{debug})
+			if tt.wantReason == "" {
+				if matched == nil {
+					t.Fatalf("expected the request to match, got %v", trace.Reasons())
+				}
+				return
+			}
+			if matched != nil {
+				t.Fatalf("expected the request to be denied")
+			}
+			if reasons := trace.Reasons(); len(reasons) != 1 || !strings.Contains(reasons[0], tt.wantReason) {
+				t.Errorf("expected a reason containing %q, got %v", tt.wantReason, reasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+const (
+	// DefaultRequestDuration is the duration of requests when neither the
+	// request nor its policy sets one
+	DefaultRequestDuration = "10m"
+
+	// DefaultPendingTimeout is how long requests wait for approval when their
+	// policy doesn't set a timeout
+	DefaultPendingTimeout = time.Hour
+)
+
+// RequestDuration returns the requested duration, or the policy's default
+// duration when the request doesn't set one
+func RequestDuration(spec *accessv1alpha1.SubjectPolicy, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if spec != nil && spec.DefaultDuration != "" {
+		return spec.DefaultDuration
+	}
+	return DefaultRequestDuration
+}
+
+// PendingTimeout returns how long a request under the policy can wait for
+// approval before it expires
+func PendingTimeout(spec *accessv1alpha1.SubjectPolicy) time.Duration {
+	if spec == nil || spec.PendingTimeout == "" {
+		return DefaultPendingTimeout
+	}
+	timeout, err := time.ParseDuration(spec.PendingTimeout)
+	if err != nil || timeout <= 0 {
+		return DefaultPendingTimeout
+	}
+	return timeout
+}
+
+// AccessStart returns when the access requested by spec starts, which is now
+// unless the request is scheduled to start later
+func AccessStart(spec *accessv1alpha1.AccessRequestBaseSpec, now time.Time) time.Time {
+	if spec.StartAt != nil && spec.StartAt.After(now) {
+		return spec.StartAt.Time
+	}
+	return now
+}
@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequestDuration(t *testing.T) {
+	withDefault := &accessv1alpha1.SubjectPolicy{DefaultDuration: "30m"}
+
+	tests := []struct {
+		name      string
+		policy    *accessv1alpha1.SubjectPolicy
+		requested string
+		want      string
+	}{
+		{name: "requested duration", policy: withDefault, requested: "5m", want: "5m"},
+		{name: "policy default", policy: withDefault, want: "30m"},
+		{name: "policy without a default", policy: &accessv1alpha1.SubjectPolicy{}, want: DefaultRequestDuration},
+		{name: "no policy", want: DefaultRequestDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestDuration(tt.policy, tt.requested); got != tt.want {
+				t.Errorf("RequestDuration() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout string
+		want    time.Duration
+	}{
+		{name: "unset", want: DefaultPendingTimeout},
+		{name: "set", timeout: "12h", want: 12 * time.Hour},
+		{name: "invalid", timeout: "tomorrow", want: DefaultPendingTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PendingTimeout(&accessv1alpha1.SubjectPolicy{PendingTimeout: tt.timeout}); got != tt.want {
+				t.Errorf("PendingTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessStart(t *testing.T) {
+	now := time.Date(2025, 6, 12, 9, 30, 0, 0, time.UTC)
+	later := metav1.NewTime(now.Add(48 * time.Hour))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name    string
+		startAt *metav1.Time
+		want    time.Time
+	}{
+		{name: "unscheduled", want: now},
+		{name: "scheduled", startAt: &later, want: later.Time},
+		{name: "start time has passed", startAt: &earlier, want: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AccessStart(&accessv1alpha1.AccessRequestBaseSpec{StartAt: tt.startAt}, now); !got.Equal(tt.want) {
+				t.Errorf("AccessStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExtendedGrant fetches the grant an extension request extends
+func ExtendedGrant(ctx context.Context, c client.Reader, req common.AccessRequestObject) (common.AccessGrantObject, error) {
+	name := req.GetSpec().Extends
+
+	var grant common.AccessGrantObject
+	if req.GetScope() == accessv1alpha1.RequestScopeCluster {
+		grant = &accessv1alpha1.ClusterAccessGrant{}
+	} else {
+		grant = &accessv1alpha1.AccessGrant{}
+	}
+
+	if err := c.Get(ctx, types.NamespacedName{Namespace: req.GetNamespace(), Name: name}, grant); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// ExtensionApprovalPolicy returns the policy extension requests are approved
+// under. Extensions need the approvals set in the policy's extension rules,
+// without approval stages, quorum groups or auto-approval.
+func ExtensionApprovalPolicy(spec accessv1alpha1.SubjectPolicy) accessv1alpha1.SubjectPolicy {
+	if spec.Extensions == nil {
+		return spec
+	}
+
+	approvers := spec.Extensions.Approvers
+	if len(approvers) == 0 {
+		approvers = AllApprovers(&spec)
+	}
+
+	extension := *spec.DeepCopy()
+	extension.Approvers = approvers
+	extension.RequiredApprovals = spec.Extensions.RequiredApprovals
+	extension.ApprovalStages = nil
+	extension.ApprovalQuorum = nil
+	extension.AutoApprovals = nil
+	return extension
+}
+
+// CheckExtension explains why the grant can't be extended by the requested
+// duration, or returns an empty string when it can
+func CheckExtension(
+	extensions *accessv1alpha1.GrantExtensions,
+	grant *accessv1alpha1.AccessGrantStatus,
+	subject string,
+	duration string,
+	now time.Time,
+) string {
+	if extensions == nil {
+		return fmt.Sprintf("policy %s does not allow grants to be extended", grant.Policy)
+	}
+	if grant.Subject != subject {
+		return fmt.Sprintf("grant for %s can't be extended by %s", grant.Subject, subject)
+	}
+	if grant.AccessExpiresAt.IsZero() || !grant.ExpiredAt.IsZero() || !now.Before(grant.AccessExpiresAt.Time) {
+		return "the grant is not active"
+	}
+
+	requested, err := time.ParseDuration(duration)
+	if err != nil {
+		return fmt.Sprintf("duration %q is invalid", duration)
+	}
+	maxTotal, err := time.ParseDuration(extensions.MaxTotalDuration)
+	if err != nil {
+		return fmt.Sprintf("maxTotalDuration %q is invalid", extensions.MaxTotalDuration)
+	}
+	total, err := GrantTotalDuration(grant)
+	if err != nil {
+		return err.Error()
+	}
+
+	if total+requested > maxTotal {
+		return fmt.Sprintf("extending the grant by %s would exceed maxTotalDuration %s, it has lasted up to %s", duration, extensions.MaxTotalDuration, total)
+	}
+	return ""
+}
+
+// GrantTotalDuration returns the grant's duration including its extensions
+func GrantTotalDuration(grant *accessv1alpha1.AccessGrantStatus) (time.Duration, error) {
+	total, err := time.ParseDuration(grant.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("grant duration %q is invalid", grant.Duration)
+	}
+	for _, extension := range grant.Extensions {
+		duration, err := time.ParseDuration(extension.Duration)
+		if err != nil {
+			return 0, fmt.Errorf("extension duration %q is invalid", extension.Duration)
+		}
+		total += duration
+	}
+	return total, nil
+}
+
+// IsExtendedBy returns true if the request has already extended the grant
+func IsExtendedBy(grant *accessv1alpha1.AccessGrantStatus, request string) bool {
+	return slices.ContainsFunc(grant.Extensions, func(extension accessv1alpha1.GrantExtension) bool {
+		return extension.Request == request
+	})
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckExtension(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	extensions := &accessv1alpha1.GrantExtensions{MaxTotalDuration: "4h", RequiredApprovals: 1}
+
+	active := func() *accessv1alpha1.AccessGrantStatus {
+		return &accessv1alpha1.AccessGrantStatus{
+			Subject:         "alice",
+			Policy:          "maintenance",
+			Duration:        "2h",
+			AccessExpiresAt: metav1.NewTime(now.Add(30 * time.Minute)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		extensions *accessv1alpha1.GrantExtensions
+		grant      func(*accessv1alpha1.AccessGrantStatus)
+		subject    string
+		duration   string
+		wantReason string
+	}{
+		{name: "within maxTotalDuration", extensions: extensions, duration: "1h"},
+		{name: "up to maxTotalDuration", extensions: extensions, duration: "2h"},
+		{name: "past maxTotalDuration", extensions: extensions, duration: "3h", wantReason: "would exceed maxTotalDuration 4h"},
+		{
+			name:       "earlier extensions count towards the total",
+			extensions: extensions,
+			grant: func(g *accessv1alpha1.AccessGrantStatus) {
+				g.Extensions = []accessv1alpha1.GrantExtension{{Request: "ext-1", Duration: "90m"}}
+			},
+			duration:   "1h",
+			wantReason: "it has lasted up to 3h30m0s",
+		},
+		{name: "policy without extensions", duration: "1h", wantReason: "does not allow grants to be extended"},
+		{name: "another subject", extensions: extensions, subject: "bob", duration: "1h", wantReason: "can't be extended by bob"},
+		{
+			name:       "expired grant",
+			extensions: extensions,
+			grant: func(g *accessv1alpha1.AccessGrantStatus) {
+				g.AccessExpiresAt = metav1.NewTime(now.Add(-time.Minute))
+			},
+			duration:   "1h",
+			wantReason: "not active",
+		},
+		{
+			name:       "retained grant",
+			extensions: extensions,
+			grant: func(g *accessv1alpha1.AccessGrantStatus) {
+				g.ExpiredAt = metav1.NewTime(now.Add(-time.Hour))
+			},
+			duration:   "1h",
+			wantReason: "not active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant := active()
+			if tt.grant != nil {
+				tt.grant(grant)
+			}
+			subject := tt.subject
+			if subject == "" {
+				subject = "alice"
+			}
+
+			reason := CheckExtension(tt.extensions, grant, subject, tt.duration, now)
+			if tt.wantReason == "" && reason != "" {
+				t.Errorf("expected the extension to be allowed, got %q", reason)
+			}
+			if tt.wantReason != "" && !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("expected a reason containing %q, got %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestExtensionApprovalPolicy(t *testing.T) {
+	spec := accessv1alpha1.SubjectPolicy{
+		RequiredApprovals: 2,
+		Approvers:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		ApprovalStages: []accessv1alpha1.ApprovalStage{
+			{Name: "security", Approvers: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "security"}}, RequiredApprovals: 1},
+		},
+		ApprovalQuorum: []accessv1alpha1.GroupQuorum{{Group: "sre", RequiredApprovals: 1}},
+		AutoApprovals:  []accessv1alpha1.AutoApprovalRule{{Name: "short", MaxDuration: "5m"}},
+	}
+
+	if got := ExtensionApprovalPolicy(spec); got.RequiredApprovals != 2 || len(got.ApprovalStages) != 1 {
+		t.Errorf("expected a policy without extensions to be unchanged, got %+v", got)
+	}
+
+	spec.Extensions = &accessv1alpha1.GrantExtensions{MaxTotalDuration: "4h", RequiredApprovals: 1}
+	got := ExtensionApprovalPolicy(spec)
+	if got.RequiredApprovals != 1 || got.ApprovalStages != nil || got.ApprovalQuorum != nil || got.AutoApprovals != nil {
+		t.Errorf("expected extension rules to replace the policy's approval rules, got %+v", got)
+	}
+	if RequiredApprovals(&got) != 1 {
+		t.Errorf("RequiredApprovals() = %d, want 1", RequiredApprovals(&got))
+	}
+	for _, user := range []struct {
+		name   string
+		groups []string
+	}{{name: "alice"}, {name: "bob", groups: []string{"security"}}, {name: "carol", groups: []string{"sre"}}} {
+		if !MatchesApprovers(got.Approvers, user.name, user.groups) {
+			t.Errorf("expected %s to be allowed to approve extensions", user.name)
+		}
+	}
+
+	spec.Extensions.Approvers = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "oncall"}}
+	got = ExtensionApprovalPolicy(spec)
+	if MatchesApprovers(got.Approvers, "alice", nil) || !MatchesApprovers(got.Approvers, "dave", []string{"oncall"}) {
+		t.Errorf("expected only the extension approvers to approve extensions, got %v", got.Approvers)
+	}
+	if len(spec.Approvers) != 1 || spec.ApprovalStages == nil {
+		t.Errorf("expected the policy to be left unchanged, got %+v", spec)
+	}
+}
@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{pattern: "payments", value: "payments", want: true},
+		{pattern: "payments", value: "payments-api"},
+		{pattern: "payments-*", value: "payments-api", want: true},
+		{pattern: "payments-*", value: "payments-", want: true},
+		{pattern: "payments-*", value: "billing-api"},
+		{pattern: "*-config", value: "app-config", want: true},
+		{pattern: "*-config", value: "app-config-backup"},
+		{pattern: "app-*-config", value: "app-web-config", want: true},
+		{pattern: "app-*-config", value: "app-config"},
+		{pattern: "*/scale", value: "deployments/scale", want: true},
+		{pattern: "*", value: "anything", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.value, func(t *testing.T) {
+			if got := globMatch(tt.pattern, tt.value); got != tt.want {
+				t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesPermissionsWithPatterns(t *testing.T) {
+	allowed := []rbacv1.PolicyRule{
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"payments-*"}, Verbs: []string{"get", "patch"}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"*-config"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"*/scale"}, Verbs: []string{"update"}},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list"}},
+	}
+
+	rule := func(group, resource string, verb string, names ...string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{APIGroups: []string{group}, Resources: []string{resource}, ResourceNames: names, Verbs: []string{verb}}
+	}
+
+	tests := []struct {
+		name      string
+		requested rbacv1.PolicyRule
+		want      bool
+	}{
+		{name: "name matching prefix", requested: rule("apps", "deployments", "patch", "payments-api", "payments-worker"), want: true},
+		{name: "name not matching prefix", requested: rule("apps", "deployments", "patch", "payments-api", "billing-api")},
+		{name: "all names when restricted", requested: rule("apps", "deployments", "patch")},
+		{name: "name matching suffix", requested: rule("", "configmaps", "get", "app-config"), want: true},
+		{name: "subresource pattern", requested: rule("apps", "statefulsets/scale", "update"), want: true},
+		{name: "subresource pattern doesn't match the resource", requested: rule("apps", "statefulsets", "update")},
+		{name: "names when unrestricted", requested: rule("", "pods", "list", "web-0"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesPermissions(allowed, []rbacv1.PolicyRule{tt.requested}); got != tt.want {
+				t.Errorf("matchesPermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// IncidentRulePrefix prefixes the name of the auto-approval rule added by an incident
+const IncidentRulePrefix = "incident-"
+
+// incidentPolicy is a policy with the overrides of an active incident applied
+type incidentPolicy struct {
+	common.AccessPolicyObject
+	policy   accessv1alpha1.SubjectPolicy
+	incident string
+}
+
+func (p *incidentPolicy) GetPolicy() accessv1alpha1.SubjectPolicy {
+	return p.policy
+}
+
+// IncidentMode returns the name of the incident whose overrides apply to the
+// policy, or an empty string when no incident applies
+func IncidentMode(policy common.AccessPolicyObject) string {
+	if p, ok := policy.(*incidentPolicy); ok {
+		return p.incident
+	}
+	return ""
+}
+
+// IncidentExpiry returns when an incident with a duration closes
+// automatically, or the zero time for incidents without one
+func IncidentExpiry(incident *accessv1alpha1.IncidentMode) time.Time {
+	if incident.Spec.Duration == "" {
+		return time.Time{}
+	}
+	duration, err := time.ParseDuration(incident.Spec.Duration)
+	if err != nil {
+		return time.Time{}
+	}
+	return incident.CreationTimestamp.Add(duration)
+}
+
+// IncidentActive returns true while the incident's overrides apply
+func IncidentActive(incident *accessv1alpha1.IncidentMode, now time.Time) bool {
+	if incident.Spec.Closed || !incident.Status.ClosedAt.IsZero() || !incident.DeletionTimestamp.IsZero() {
+		return false
+	}
+	expiresAt := IncidentExpiry(incident)
+	return expiresAt.IsZero() || now.Before(expiresAt)
+}
+
+// activeIncidents returns the incidents that are active at the given time,
+// oldest first
+func activeIncidents(incidents []accessv1alpha1.IncidentMode, now time.Time) []accessv1alpha1.IncidentMode {
+	var active []accessv1alpha1.IncidentMode
+	for i := range incidents {
+		if IncidentActive(&incidents[i], now) {
+			active = append(active, *incidents[i].DeepCopy())
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		if !active[i].CreationTimestamp.Equal(&active[j].CreationTimestamp) {
+			return active[i].CreationTimestamp.Before(&active[j].CreationTimestamp)
+		}
+		return active[i].Name < active[j].Name
+	})
+
+	return active
+}
+
+// applyIncidents returns the policy with the overrides of the oldest active
+// incident selecting it applied. Policies no incident selects are returned unchanged.
+func applyIncidents(
+	policy common.AccessPolicyObject,
+	incidents []accessv1alpha1.IncidentMode,
+) common.AccessPolicyObject {
+	for i := range incidents {
+		incident := &incidents[i]
+		if !selectsPolicy(incident, policy) {
+			continue
+		}
+
+		return &incidentPolicy{
+			AccessPolicyObject: policy,
+			policy:             MergeIncidentOverrides(policy.GetPolicy(), incident),
+			incident:           incident.Name,
+		}
+	}
+
+	return policy
+}
+
+func selectsPolicy(incident *accessv1alpha1.IncidentMode, policy common.AccessPolicyObject) bool {
+	if incident.Spec.PolicySelector == nil {
+		return true
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(incident.Spec.PolicySelector)
+	if err != nil {
+		return false
+	}
+
+	return selector.Matches(labels.Set(policy.GetLabels()))
+}
+
+// MergeIncidentOverrides relaxes the policy with the incident's overrides.
+// Overrides that would make the policy stricter are ignored.
+func MergeIncidentOverrides(
+	spec accessv1alpha1.SubjectPolicy,
+	incident *accessv1alpha1.IncidentMode,
+) accessv1alpha1.SubjectPolicy {
+	merged := *spec.DeepCopy()
+	overrides := incident.Spec.Overrides
+
+	// Approvals are counted from any of the policy's approvers instead of by
+	// stage or quorum group
+	if overrides.RequiredApprovals != nil && *overrides.RequiredApprovals < RequiredApprovals(&spec) {
+		merged.Approvers = AllApprovers(&spec)
+		merged.RequiredApprovals = *overrides.RequiredApprovals
+		merged.ApprovalStages = nil
+		merged.ApprovalQuorum = nil
+	}
+
+	if overrides.MaxDuration != "" && !matchesDuration(merged.MaxDuration, overrides.MaxDuration) {
+		merged.MaxDuration = overrides.MaxDuration
+	}
+
+	if len(overrides.AutoApproveGroups) > 0 {
+		groups := make([]string, 0, len(overrides.AutoApproveGroups))
+		for _, group := range overrides.AutoApproveGroups {
+			groups = append(groups, strconv.Quote(group))
+		}
+
+		rule := accessv1alpha1.AutoApprovalRule{
+			Name:   IncidentRulePrefix + incident.Name,
+			Reason: fmt.Sprintf("auto-approved for responders to incident %s", incident.Name),
+			Conditions: []accessv1alpha1.PolicyCondition{{
+				Expression: fmt.Sprintf("groups.exists(g, g in [%s])", strings.Join(groups, ", ")),
+			}},
+		}
+		merged.AutoApprovals = append([]accessv1alpha1.AutoApprovalRule{rule}, merged.AutoApprovals...)
+	}
+
+	return merged
+}
@@ -0,0 +1,185 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIncidentActive(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	opened := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	incident := func(spec accessv1alpha1.IncidentModeSpec, status accessv1alpha1.IncidentModeStatus) *accessv1alpha1.IncidentMode {
+		return &accessv1alpha1.IncidentMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "outage", CreationTimestamp: opened},
+			Spec:       spec,
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		incident *accessv1alpha1.IncidentMode
+		want     bool
+	}{
+		{name: "open incident", incident: incident(accessv1alpha1.IncidentModeSpec{}, accessv1alpha1.IncidentModeStatus{}), want: true},
+		{name: "closed incident", incident: incident(accessv1alpha1.IncidentModeSpec{Closed: true}, accessv1alpha1.IncidentModeStatus{})},
+		{name: "incident within its duration", incident: incident(accessv1alpha1.IncidentModeSpec{Duration: "4h"}, accessv1alpha1.IncidentModeStatus{}), want: true},
+		{name: "incident past its duration", incident: incident(accessv1alpha1.IncidentModeSpec{Duration: "1h"}, accessv1alpha1.IncidentModeStatus{})},
+		{name: "incident recorded as closed", incident: incident(accessv1alpha1.IncidentModeSpec{}, accessv1alpha1.IncidentModeStatus{ClosedAt: metav1.NewTime(now)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IncidentActive(tt.incident, now); got != tt.want {
+				t.Errorf("IncidentActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeIncidentOverrides(t *testing.T) {
+	one, three := 1, 3
+	sre := rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "sre"}
+	leads := rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "leads"}
+
+	spec := accessv1alpha1.SubjectPolicy{
+		MaxDuration: "1h",
+		ApprovalStages: []accessv1alpha1.ApprovalStage{
+			{Name: "team-lead", Approvers: []rbacv1.Subject{leads}, RequiredApprovals: 1},
+			{Name: "sre", Approvers: []rbacv1.Subject{sre}, RequiredApprovals: 1},
+		},
+		AutoApprovals: []accessv1alpha1.AutoApprovalRule{{Name: "read-only", Verbs: []string{"get"}}},
+	}
+
+	incident := func(overrides accessv1alpha1.IncidentOverrides) *accessv1alpha1.IncidentMode {
+		return &accessv1alpha1.IncidentMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "outage"},
+			Spec:       accessv1alpha1.IncidentModeSpec{Overrides: overrides},
+		}
+	}
+
+	merged := MergeIncidentOverrides(spec, incident(accessv1alpha1.IncidentOverrides{
+		RequiredApprovals: &one,
+		MaxDuration:       "8h",
+		AutoApproveGroups: []string{"responders"},
+	}))
+	if merged.RequiredApprovals != 1 || len(merged.ApprovalStages) != 0 || len(merged.Approvers) != 2 {
+		t.Errorf("expected one approval from any stage's approvers, got %+v", merged)
+	}
+	if merged.MaxDuration != "8h" {
+		t.Errorf("expected maxDuration to be raised, got %q", merged.MaxDuration)
+	}
+	if len(merged.AutoApprovals) != 2 || merged.AutoApprovals[0].Name != "incident-outage" {
+		t.Errorf("expected the incident's auto-approval rule first, got %+v", merged.AutoApprovals)
+	}
+	if len(spec.ApprovalStages) != 2 || len(spec.AutoApprovals) != 1 {
+		t.Errorf("expected the policy to be left unchanged")
+	}
+
+	// Overrides never make a policy stricter
+	merged = MergeIncidentOverrides(spec, incident(accessv1alpha1.IncidentOverrides{
+		RequiredApprovals: &three,
+		MaxDuration:       "30m",
+	}))
+	if len(merged.ApprovalStages) != 2 || merged.MaxDuration != "1h" {
+		t.Errorf("expected the policy to be unchanged, got %+v", merged)
+	}
+}
+
+func TestIncidentAutoApproval(t *testing.T) {
+	merged := MergeIncidentOverrides(accessv1alpha1.SubjectPolicy{}, &accessv1alpha1.IncidentMode{
+		ObjectMeta: metav1.ObjectMeta{Name: "outage"},
+		Spec: accessv1alpha1.IncidentModeSpec{
+			Overrides: accessv1alpha1.IncidentOverrides{AutoApproveGroups: []string{"responders", "sre"}},
+		},
+	})
+
+	request := func(groups ...string) common.AccessRequestObject {
+		return &accessv1alpha1.ClusterAccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req"},
+			Spec: accessv1alpha1.ClusterAccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{Subject: "alice", Groups: groups, Duration: "1h"},
+			},
+		}
+	}
+
+	resolver := &PolicyResolver{}
+
+	rule, _, err := resolver.AutoApprove(context.Background(), request("dev", "sre"), merged.AutoApprovals, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule == nil || rule.Name != "incident-outage" {
+		t.Errorf("expected a responder's request to be auto-approved, got %+v", rule)
+	}
+
+	rule, _, err = resolver.AutoApprove(context.Background(), request("dev"), merged.AutoApprovals, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule != nil {
+		t.Errorf("expected other requests to need approval, got %+v", rule)
+	}
+}
+
+func TestPolicyIncidents(t *testing.T) {
+	now := time.Now()
+	zero := 0
+
+	payments := &accessv1alpha1.ClusterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}},
+		Spec: accessv1alpha1.ClusterAccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{RequiredApprovals: 2, MaxDuration: "1h"},
+		},
+	}
+	search := &accessv1alpha1.ClusterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Labels: map[string]string{"team": "search"}},
+		Spec: accessv1alpha1.ClusterAccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{RequiredApprovals: 2, MaxDuration: "1h"},
+		},
+	}
+
+	outage := accessv1alpha1.IncidentMode{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-outage", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		Spec: accessv1alpha1.IncidentModeSpec{
+			PolicySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			Overrides:      accessv1alpha1.IncidentOverrides{RequiredApprovals: &zero},
+		},
+	}
+
+	m := NewPolicyManager()
+	m.Update([]common.AccessPolicyObject{payments, search})
+	m.UpdateIncidents([]accessv1alpha1.IncidentMode{outage}, now)
+
+	byName := func() map[string]common.AccessPolicyObject {
+		policies := map[string]common.AccessPolicyObject{}
+		for _, p := range m.GetSnapshot() {
+			policies[p.GetName()] = p
+		}
+		return policies
+	}
+
+	policies := byName()
+	if got := policies["payments"]; IncidentMode(got) != "payments-outage" || got.GetPolicy().RequiredApprovals != 0 {
+		t.Errorf("expected the incident to apply to payments, got %+v", got.GetPolicy())
+	}
+	if got := policies["search"]; IncidentMode(got) != "" || got.GetPolicy().RequiredApprovals != 2 {
+		t.Errorf("expected search to be unchanged, got %+v", got.GetPolicy())
+	}
+
+	// Closing the incident reverts the policies
+	outage.Spec.Closed = true
+	m.UpdateIncidents([]accessv1alpha1.IncidentMode{outage}, now)
+
+	policies = byName()
+	if got := policies["payments"]; IncidentMode(got) != "" || got.GetPolicy().RequiredApprovals != 2 {
+		t.Errorf("expected payments to be reverted, got %+v", got.GetPolicy())
+	}
+}
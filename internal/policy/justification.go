@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+// ValidateJustification checks the justification fields of a request against
+// the policy's schema. All violations are returned together. Requests are not
+// checked when the policy has no schema.
+func ValidateJustification(schema []accessv1alpha1.JustificationField, fields map[string]string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	var msgs []string
+
+	for _, field := range schema {
+		value, ok := fields[field.Name]
+		value = strings.TrimSpace(value)
+
+		if !ok || value == "" {
+			if field.Required {
+				msgs = append(msgs, fmt.Sprintf("justification field %q is required", field.Name))
+			}
+			continue
+		}
+
+		if len(value) < field.MinLength {
+			msgs = append(msgs, fmt.Sprintf("justification field %q must be at least %d characters", field.Name, field.MinLength))
+		}
+
+		if field.Pattern != "" {
+			re, err := regexp.Compile(field.Pattern)
+			if err != nil {
+				msgs = append(msgs, fmt.Sprintf("justification field %q has an invalid pattern in the policy: %s", field.Name, err))
+			} else if !re.MatchString(value) {
+				msgs = append(msgs, fmt.Sprintf("justification field %q must match %q", field.Name, field.Pattern))
+			}
+		}
+	}
+
+	// Only fields from the schema are accepted so records stay consistent
+	var unknown []string
+	for name := range fields {
+		if !slices.ContainsFunc(schema, func(f accessv1alpha1.JustificationField) bool { return f.Name == name }) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		msgs = append(msgs, fmt.Sprintf("unknown justification fields: %s", strings.Join(unknown, ", ")))
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+func TestValidateJustification(t *testing.T) {
+	schema := []accessv1alpha1.JustificationField{
+		{Name: "ticket", Required: true, Pattern: "^INC-[0-9]+$"},
+		{Name: "reason", Required: true, MinLength: 10},
+		{Name: "customer"},
+	}
+
+	tests := []struct {
+		name    string
+		schema  []accessv1alpha1.JustificationField
+		fields  map[string]string
+		wantErr []string
+	}{
+		{
+			name:   "no schema accepts anything",
+			fields: map[string]string{"anything": "debug"},
+		},
+		{
+			name:   "valid fields",
+			schema: schema,
+			fields: map[string]string{"ticket": "INC-1234", "reason": "investigating failed deploys"},
+		},
+		{
+			name:    "missing required fields",
+			schema:  schema,
+			fields:  map[string]string{"ticket": "  "},
+			wantErr: []string{`"ticket" is required`, `"reason" is required`},
+		},
+		{
+			name:    "pattern and minimum length",
+			schema:  schema,
+			fields:  map[string]string{"ticket": "debug", "reason": "debug"},
+			wantErr: []string{`"ticket" must match`, `"reason" must be at least 10 characters`},
+		},
+		{
+			name:    "unknown fields",
+			schema:  schema,
+			fields:  map[string]string{"ticket": "INC-1", "reason": "investigating failed deploys", "note": "x"},
+			wantErr: []string{"unknown justification fields: note"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJustification(tt.schema, tt.fields)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %q", want, err)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	common "github.com/itsthatdude/jit-access-controller/internal/common"
@@ -11,8 +12,11 @@ import (
 )
 
 type PolicyManager struct {
-	mu       sync.RWMutex
-	policies []common.AccessPolicyObject
+	mu        sync.RWMutex
+	policies  []common.AccessPolicyObject
+	raw       []common.AccessPolicyObject
+	templates map[string]accessv1alpha1.AccessPolicyTemplateSpec
+	incidents []accessv1alpha1.IncidentMode
 }
 
 func NewPolicyManager() *PolicyManager {
@@ -23,8 +27,55 @@ func (m *PolicyManager) Update(
 	policies []common.AccessPolicyObject,
 ) {
 	// Defensive copy
-	snapshot := make([]common.AccessPolicyObject, len(policies))
-	copy(snapshot, policies)
+	raw := make([]common.AccessPolicyObject, len(policies))
+	copy(raw, policies)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.raw = raw
+	m.rebuild()
+}
+
+// UpdateTemplates replaces the known policy templates and rebuilds the
+// snapshot of the policies referencing them.
+func (m *PolicyManager) UpdateTemplates(
+	templates []accessv1alpha1.AccessPolicyTemplate,
+) {
+	specs := make(map[string]accessv1alpha1.AccessPolicyTemplateSpec, len(templates))
+	for _, tmpl := range templates {
+		specs[tmpl.Name] = *tmpl.Spec.DeepCopy()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.templates = specs
+	m.rebuild()
+}
+
+// UpdateIncidents replaces the known incidents and rebuilds the snapshot with
+// the overrides of the incidents that are active at the given time.
+func (m *PolicyManager) UpdateIncidents(
+	incidents []accessv1alpha1.IncidentMode,
+	now time.Time,
+) {
+	active := activeIncidents(incidents, now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.incidents = active
+	m.rebuild()
+}
+
+// rebuild applies templates and incident overrides to the policies and sorts
+// them. The caller must hold the write lock.
+func (m *PolicyManager) rebuild() {
+	snapshot := make([]common.AccessPolicyObject, 0, len(m.raw))
+	for _, policy := range m.raw {
+		snapshot = append(snapshot, applyIncidents(applyTemplate(policy, m.templates), m.incidents))
+	}
 
 	// Sort by priority DESC, name ASC (deterministic)
 	sort.Slice(snapshot, func(i, j int) bool {
@@ -41,12 +92,18 @@ func (m *PolicyManager) Update(
 		return name < lastName
 	})
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	m.policies = snapshot
 }
 
+// TemplateExists returns true if the named AccessPolicyTemplate is known
+func (m *PolicyManager) TemplateExists(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.templates[name]
+	return ok
+}
+
 func (m *PolicyManager) GetSnapshot() []common.AccessPolicyObject {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -91,3 +148,32 @@ func LoadNamespacedPolicies(ctx context.Context, c client.Client, manager *Polic
 	manager.Update(objs)
 	return nil
 }
+
+// LoadIncidentModes lists all IncidentMode resources and updates the provided
+// PolicyManagers with the active ones.
+func LoadIncidentModes(ctx context.Context, c client.Client, managers ...*PolicyManager) error {
+	var list accessv1alpha1.IncidentModeList
+	if err := c.List(ctx, &list); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, manager := range managers {
+		manager.UpdateIncidents(list.Items, now)
+	}
+	return nil
+}
+
+// LoadPolicyTemplates lists all AccessPolicyTemplate resources and updates the
+// provided PolicyManagers with them.
+func LoadPolicyTemplates(ctx context.Context, c client.Client, managers ...*PolicyManager) error {
+	var list accessv1alpha1.AccessPolicyTemplateList
+	if err := c.List(ctx, &list); err != nil {
+		return err
+	}
+
+	for _, manager := range managers {
+		manager.UpdateTemplates(list.Items)
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package policy
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -70,3 +73,74 @@ func TestLoadPolicies(t *testing.T) {
 		t.Errorf("unexpected namespaced policy name: %s", snap[0].GetName())
 	}
 }
+
+func TestPolicyTemplates(t *testing.T) {
+	debugPods := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}}
+	viewPods := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}
+
+	template := func(rules []rbacv1.PolicyRule) accessv1alpha1.AccessPolicyTemplate {
+		return accessv1alpha1.AccessPolicyTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug-pods"},
+			Spec: accessv1alpha1.AccessPolicyTemplateSpec{
+				AllowedPermissions: rules,
+				MaxDuration:        "30m",
+				Approvers:          []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+			},
+		}
+	}
+
+	inherits := &accessv1alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a"},
+		Spec: accessv1alpha1.AccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{Template: "debug-pods"},
+		},
+	}
+	overrides := &accessv1alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "team-b"},
+		Spec: accessv1alpha1.AccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{Template: "debug-pods", MaxDuration: "5m"},
+		},
+	}
+	missing := &accessv1alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-c", Namespace: "team-c"},
+		Spec: accessv1alpha1.AccessPolicySpec{
+			SubjectPolicy: accessv1alpha1.SubjectPolicy{Template: "unknown"},
+		},
+	}
+
+	m := NewPolicyManager()
+	m.UpdateTemplates([]accessv1alpha1.AccessPolicyTemplate{template(debugPods)})
+	m.Update([]common.AccessPolicyObject{inherits, overrides, missing})
+
+	byName := func() map[string]accessv1alpha1.SubjectPolicy {
+		policies := map[string]accessv1alpha1.SubjectPolicy{}
+		for _, p := range m.GetSnapshot() {
+			policies[p.GetName()] = p.GetPolicy()
+		}
+		return policies
+	}
+
+	policies := byName()
+	if got := policies["team-a"]; got.MaxDuration != "30m" || len(got.Approvers) != 1 || !reflect.DeepEqual(got.AllowedPermissions, debugPods) {
+		t.Errorf("expected team-a to inherit the template, got %+v", got)
+	}
+	if got := policies["team-b"]; got.MaxDuration != "5m" || !reflect.DeepEqual(got.AllowedPermissions, debugPods) {
+		t.Errorf("expected team-b to override the template's duration, got %+v", got)
+	}
+	if got := policies["team-c"]; got.MaxDuration != "" || len(got.AllowedPermissions) != 0 {
+		t.Errorf("expected team-c to be unchanged, got %+v", got)
+	}
+
+	// Changing the template updates the policies referencing it
+	m.UpdateTemplates([]accessv1alpha1.AccessPolicyTemplate{template(viewPods)})
+
+	policies = byName()
+	for _, name := range []string{"team-a", "team-b"} {
+		if !reflect.DeepEqual(policies[name].AllowedPermissions, viewPods) {
+			t.Errorf("expected %s to use the updated template, got %+v", name, policies[name].AllowedPermissions)
+		}
+	}
+	if inherits.Spec.AllowedPermissions != nil {
+		t.Errorf("expected the policy object to be left unchanged")
+	}
+}
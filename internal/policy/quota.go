@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	quotaDay  = 24 * time.Hour
+	quotaWeek = 7 * quotaDay
+)
+
+// QuotaRetention returns how long expired grants need to be kept so they can be
+// counted towards the quota. Zero means expired grants aren't needed.
+func QuotaRetention(quota *accessv1alpha1.SubjectQuota) time.Duration {
+	if quota == nil {
+		return 0
+	}
+
+	var retention time.Duration
+	if quota.MaxGrantsPerDay > 0 {
+		retention = quotaDay
+	}
+	if quota.MaxGrantsPerWeek > 0 {
+		retention = quotaWeek
+	}
+	if cooldown, err := time.ParseDuration(quota.Cooldown); err == nil && cooldown > retention {
+		retention = cooldown
+	}
+
+	return retention
+}
+
+// CheckQuota counts the grants the subject of the request already holds in the
+// request's scope and returns a message describing the first limit of the quota
+// that granting the request would exceed. The grant belonging to the request
+// itself is not counted.
+func CheckQuota(
+	ctx context.Context,
+	c client.Client,
+	req common.AccessRequestObject,
+	quota *accessv1alpha1.SubjectQuota,
+	now time.Time,
+) (string, error) {
+	if quota == nil {
+		return "", nil
+	}
+
+	grants, err := listSubjectGrants(ctx, c, req)
+	if err != nil {
+		return "", err
+	}
+
+	return evaluateQuota(quota, req.GetSubject(), grants, now), nil
+}
+
+// quotaEntry is the part of an existing grant that counts towards a quota
+type quotaEntry struct {
+	grantedAt time.Time
+	expiredAt time.Time
+}
+
+func evaluateQuota(quota *accessv1alpha1.SubjectQuota, subject string, grants []quotaEntry, now time.Time) string {
+	var cooldown time.Duration
+	if quota.Cooldown != "" {
+		// The CRD validates the pattern, so this only fails on overflow
+		cooldown, _ = time.ParseDuration(quota.Cooldown)
+	}
+
+	active, lastDay, lastWeek := 0, 0, 0
+	var lastExpiry time.Time
+
+	for _, grant := range grants {
+		if grant.expiredAt.IsZero() {
+			active++
+		} else if grant.expiredAt.After(lastExpiry) {
+			lastExpiry = grant.expiredAt
+		}
+
+		if now.Sub(grant.grantedAt) < quotaDay {
+			lastDay++
+		}
+		if now.Sub(grant.grantedAt) < quotaWeek {
+			lastWeek++
+		}
+	}
+
+	switch {
+	case quota.MaxActiveGrants > 0 && active >= quota.MaxActiveGrants:
+		return fmt.Sprintf("%s already holds %d active grants (maximum %d)", subject, active, quota.MaxActiveGrants)
+	case quota.MaxGrantsPerDay > 0 && lastDay >= quota.MaxGrantsPerDay:
+		return fmt.Sprintf("%s has received %d grants in the last 24h (maximum %d)", subject, lastDay, quota.MaxGrantsPerDay)
+	case quota.MaxGrantsPerWeek > 0 && lastWeek >= quota.MaxGrantsPerWeek:
+		return fmt.Sprintf("%s has received %d grants in the last 7d (maximum %d)", subject, lastWeek, quota.MaxGrantsPerWeek)
+	case cooldown > 0 && !lastExpiry.IsZero() && now.Before(lastExpiry.Add(cooldown)):
+		return fmt.Sprintf("%s is in a cooldown period until %s", subject, lastExpiry.Add(cooldown).Format(time.RFC3339))
+	}
+
+	return ""
+}
+
+func listSubjectGrants(ctx context.Context, c client.Client, req common.AccessRequestObject) ([]quotaEntry, error) {
+	var grants []common.AccessGrantObject
+
+	if req.GetScope() == accessv1alpha1.RequestScopeCluster {
+		var list accessv1alpha1.ClusterAccessGrantList
+		if err := c.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list ClusterAccessGrants: %w", err)
+		}
+		for i := range list.Items {
+			grants = append(grants, &list.Items[i])
+		}
+	} else {
+		var list accessv1alpha1.AccessGrantList
+		if err := c.List(ctx, &list, client.InNamespace(req.GetNamespace())); err != nil {
+			return nil, fmt.Errorf("failed to list AccessGrants: %w", err)
+		}
+		for i := range list.Items {
+			grants = append(grants, &list.Items[i])
+		}
+	}
+
+	requestId := req.GetStatus().RequestId
+
+	var entries []quotaEntry
+	for _, grant := range grants {
+		status := grant.GetStatus()
+		if status.Subject != req.GetSubject() || (requestId != "" && status.RequestId == requestId) {
+			continue
+		}
+		entries = append(entries, quotaEntry{
+			grantedAt: grant.GetCreationTimestamp().Time,
+			expiredAt: status.ExpiredAt.Time,
+		})
+	}
+
+	return entries, nil
+}
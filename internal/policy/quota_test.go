@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEvaluateQuota(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+	active := quotaEntry{grantedAt: now.Add(-time.Hour)}
+	expiredToday := quotaEntry{grantedAt: now.Add(-3 * time.Hour), expiredAt: now.Add(-2 * time.Hour)}
+	expiredLastWeek := quotaEntry{grantedAt: now.Add(-72 * time.Hour), expiredAt: now.Add(-71 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		quota   *accessv1alpha1.SubjectQuota
+		grants  []quotaEntry
+		wantMsg string
+	}{
+		{
+			name:   "within all limits",
+			quota:  &accessv1alpha1.SubjectQuota{MaxActiveGrants: 2, MaxGrantsPerDay: 3, MaxGrantsPerWeek: 4},
+			grants: []quotaEntry{active, expiredToday, expiredLastWeek},
+		},
+		{
+			name:    "too many active grants",
+			quota:   &accessv1alpha1.SubjectQuota{MaxActiveGrants: 1},
+			grants:  []quotaEntry{active, expiredToday},
+			wantMsg: "active grants",
+		},
+		{
+			name:    "daily limit reached",
+			quota:   &accessv1alpha1.SubjectQuota{MaxGrantsPerDay: 2},
+			grants:  []quotaEntry{active, expiredToday, expiredLastWeek},
+			wantMsg: "last 24h",
+		},
+		{
+			name:    "weekly limit reached",
+			quota:   &accessv1alpha1.SubjectQuota{MaxGrantsPerWeek: 3},
+			grants:  []quotaEntry{active, expiredToday, expiredLastWeek},
+			wantMsg: "last 7d",
+		},
+		{
+			name:    "in cooldown",
+			quota:   &accessv1alpha1.SubjectQuota{Cooldown: "3h"},
+			grants:  []quotaEntry{expiredToday},
+			wantMsg: "cooldown",
+		},
+		{
+			name:   "cooldown has passed",
+			quota:  &accessv1alpha1.SubjectQuota{Cooldown: "1h"},
+			grants: []quotaEntry{expiredToday},
+		},
+		{
+			name:   "unset limits are ignored",
+			quota:  &accessv1alpha1.SubjectQuota{},
+			grants: []quotaEntry{active, active, expiredToday},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := evaluateQuota(tt.quota, "alice", tt.grants, now)
+			if tt.wantMsg == "" && msg != "" {
+				t.Fatalf("expected no violation, got %q", msg)
+			}
+			if !strings.Contains(msg, tt.wantMsg) {
+				t.Errorf("expected violation containing %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	ctx := context.Background()
+
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+	if err := accessv1alpha1.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add access scheme: %v", err)
+	}
+
+	grant := func(name, namespace, subject, requestId string) *accessv1alpha1.AccessGrant {
+		return &accessv1alpha1.AccessGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     accessv1alpha1.AccessGrantStatus{Subject: subject, RequestId: requestId},
+		}
+	}
+
+	fakeClient := ctrlclient.NewClientBuilder().WithScheme(sch).
+		WithObjects(
+			grant("alice-1", "default", "alice", "a1"),
+			grant("alice-2", "other", "alice", "a2"),
+			grant("bob-1", "default", "bob", "b1"),
+		).
+		WithStatusSubresource(&accessv1alpha1.AccessGrant{}).
+		Build()
+
+	quota := &accessv1alpha1.SubjectQuota{MaxActiveGrants: 1}
+
+	req := &accessv1alpha1.AccessRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"},
+		Spec: accessv1alpha1.AccessRequestSpec{
+			AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{Subject: "alice"},
+		},
+	}
+
+	msg, err := CheckQuota(ctx, fakeClient, req, quota, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg == "" {
+		t.Errorf("expected alice's grant in the request namespace to count towards the quota")
+	}
+
+	// The grant created for the request itself isn't counted
+	req.Status.RequestId = "a1"
+	msg, err = CheckQuota(ctx, fakeClient, req, quota, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg != "" {
+		t.Errorf("expected no violation for the request's own grant, got %q", msg)
+	}
+
+	msg, err = CheckQuota(ctx, fakeClient, req, nil, time.Now())
+	if err != nil || msg != "" {
+		t.Errorf("expected a nil quota to allow the request, got %q, %v", msg, err)
+	}
+}
@@ -1,42 +1,332 @@
 package policy
 
 import (
+	"context"
+	"fmt"
 	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-type PolicyResolver struct{}
+// PolicyResolver finds the policy that authorizes a request.
+type PolicyResolver struct {
+	// ClusterPolicies, when set, are also considered for namespaced requests.
+	// Only cluster policies with a namespaceSelector matching the request's
+	// namespace apply.
+	ClusterPolicies *PolicyManager
+	// Client is used to look up namespace labels for namespaceSelector matching,
+	// and the rules of requested roles for deniedPermissions
+	Client client.Reader
+}
 
 func (r *PolicyResolver) Resolve(
+	ctx context.Context,
 	req common.AccessRequestObject,
 	policies []common.AccessPolicyObject,
 ) common.AccessPolicyObject {
-	for _, policy := range policies {
-		if req.GetNamespace() == policy.GetNamespace() {
-			if matchesPolicy(policy, req) {
-				return policy
-			}
+	policy, _ := r.ResolveWithTrace(ctx, req, policies)
+	return policy
+}
+
+// PolicyEvaluation records the outcome of checking a request against a policy
+type PolicyEvaluation struct {
+	Policy    string
+	Namespace string
+	Matched   bool
+	// Reason is the first check of the policy the request failed
+	Reason string
+}
+
+// Trace lists the policies a request was checked against, in evaluation order
+type Trace []PolicyEvaluation
+
+// Reasons returns a message for each policy that rejected the request
+func (t Trace) Reasons() []string {
+	var reasons []string
+	for _, eval := range t {
+		if !eval.Matched {
+			reasons = append(reasons, fmt.Sprintf("policy %s: %s", eval.Policy, eval.Reason))
 		}
 	}
-
-	return nil
+	return reasons
 }
 
-func matchesPolicy(
-	policy common.AccessPolicyObject,
+// ResolveWithTrace behaves like Resolve, and also returns the trace of the
+// policies that were evaluated along with the reason each one didn't match.
+func (r *PolicyResolver) ResolveWithTrace(
+	ctx context.Context,
 	req common.AccessRequestObject,
-) bool {
+	policies []common.AccessPolicyObject,
+) (common.AccessPolicyObject, Trace) {
+	rc := &resolveContext{ctx: ctx, client: r.Client, req: req}
+
+	candidates := policies
+	if req.GetScope() == accessv1alpha1.RequestScopeNamespace {
+		candidates = append(slices.Clone(policies), r.namespaceSelectorPolicies(rc)...)
+
+		// Keep the highest priority first; namespaced policies win ties
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].GetPolicy().Priority > candidates[j].GetPolicy().Priority
+		})
+	}
+
+	var trace Trace
+	for _, policy := range candidates {
+		if !appliesTo(policy, req) {
+			continue
+		}
+
+		matched, reason := matchesPolicy(rc, policy)
+		trace = append(trace, PolicyEvaluation{
+			Policy:    policy.GetName(),
+			Namespace: policy.GetNamespace(),
+			Matched:   matched,
+			Reason:    reason,
+		})
+		if matched {
+			return policy, trace
+		}
+	}
+
+	return nil, trace
+}
+
+// resolveContext holds the request being resolved and lazily loads the labels
+// of its namespace, which are shared between policies.
+type resolveContext struct {
+	ctx    context.Context
+	client client.Reader
+	req    common.AccessRequestObject
+
+	nsLoaded bool
+	nsLabels map[string]string
+	nsErr    error
+
+	rulesLoaded bool
+	roleRules   []rbacv1.PolicyRule
+	rulesErr    error
+}
+
+func (c *resolveContext) namespaceLabels() (map[string]string, error) {
+	if c.nsLoaded {
+		return c.nsLabels, c.nsErr
+	}
+	c.nsLoaded = true
+
+	if c.req.GetNamespace() == "" {
+		return nil, nil
+	}
+	if c.client == nil {
+		c.nsErr = fmt.Errorf("no client configured to look up namespace %s", c.req.GetNamespace())
+		return nil, c.nsErr
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Name: c.req.GetNamespace()}, ns); err != nil {
+		c.nsErr = fmt.Errorf("unable to fetch namespace %s: %w", c.req.GetNamespace(), err)
+		return nil, c.nsErr
+	}
+	c.nsLabels = ns.Labels
+
+	return c.nsLabels, nil
+}
+
+// requestedRoleRules returns the rules of the requested role. Roles are looked
+// up in the request's namespace.
+func (c *resolveContext) requestedRoleRules() ([]rbacv1.PolicyRule, error) {
+	if c.rulesLoaded {
+		return c.roleRules, c.rulesErr
+	}
+	c.rulesLoaded = true
+
+	ref := c.req.GetSpec().Role
+	if c.client == nil {
+		c.rulesErr = fmt.Errorf("no client configured to look up %s %s", ref.Kind, ref.Name)
+		return nil, c.rulesErr
+	}
+
+	switch ref.Kind {
+	case common.RoleKindCluster:
+		role := &rbacv1.ClusterRole{}
+		if err := c.client.Get(c.ctx, client.ObjectKey{Name: ref.Name}, role); err != nil {
+			c.rulesErr = fmt.Errorf("unable to fetch %s %s: %w", ref.Kind, ref.Name, err)
+			return nil, c.rulesErr
+		}
+		c.roleRules = role.Rules
+	case common.RoleKindRole:
+		role := &rbacv1.Role{}
+		if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: c.req.GetNamespace(), Name: ref.Name}, role); err != nil {
+			c.rulesErr = fmt.Errorf("unable to fetch %s %s: %w", ref.Kind, ref.Name, err)
+			return nil, c.rulesErr
+		}
+		c.roleRules = role.Rules
+	default:
+		c.rulesErr = fmt.Errorf("role kind %q is not supported", ref.Kind)
+	}
+
+	return c.roleRules, c.rulesErr
+}
+
+// appliesTo reports whether the policy covers the scope of the request. Cluster
+// policies with a namespaceSelector only cover namespaced requests, and are
+// only passed in for namespaces their selector matches.
+func appliesTo(policy common.AccessPolicyObject, req common.AccessRequestObject) bool {
+	if policy.GetNamespaceSelector() != nil {
+		return req.GetScope() == accessv1alpha1.RequestScopeNamespace
+	}
+	return req.GetNamespace() == policy.GetNamespace()
+}
+
+// namespaceSelectorPolicies returns the cluster policies whose namespaceSelector
+// matches the labels of the request's namespace.
+func (r *PolicyResolver) namespaceSelectorPolicies(rc *resolveContext) []common.AccessPolicyObject {
+	if r.ClusterPolicies == nil || r.Client == nil {
+		return nil
+	}
+
+	log := logf.FromContext(rc.ctx)
+	var matched []common.AccessPolicyObject
+
+	for _, policy := range r.ClusterPolicies.GetSnapshot() {
+		if policy.GetNamespaceSelector() == nil {
+			continue
+		}
+
+		nsLabels, err := rc.namespaceLabels()
+		if err != nil {
+			log.Error(err, "unable to match namespaceSelector")
+			return nil
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(policy.GetNamespaceSelector())
+		if err != nil {
+			log.Error(err, "invalid namespaceSelector on policy", "policy", policy.GetName())
+			continue
+		}
+
+		if selector.Matches(labels.Set(nsLabels)) {
+			matched = append(matched, policy)
+		}
+	}
+
+	return matched
+}
+
+// matchesPolicy checks the request against the policy, and returns the reason
+// of the first check that failed.
+func matchesPolicy(rc *resolveContext, policy common.AccessPolicyObject) (bool, string) {
 	var policySpec = policy.GetPolicy()
-	var reqSpec = req.GetSpec()
+	var reqSpec = rc.req.GetSpec()
 
-	return matchesSubjects(policySpec.Requesters, reqSpec.Subject, reqSpec.Groups) &&
-		matchesDuration(policySpec.MaxDuration, reqSpec.Duration) &&
-		matchesPermissions(policySpec.AllowedPermissions, reqSpec.Permissions) &&
-		matchesRoles(policySpec.AllowedRoles, reqSpec.Role)
+	// Delegated requests are made by the delegator with their own groups, for a
+	// subject who must be a requester themselves
+	if reqSpec.Delegator != "" {
+		if len(policySpec.AllowDelegation) == 0 || !matchesSubjects(policySpec.AllowDelegation, reqSpec.Delegator, reqSpec.DelegatorGroups) {
+			return false, fmt.Sprintf("%s is not allowed to request access on behalf of %s", reqSpec.Delegator, reqSpec.Subject)
+		}
+	}
+	if !matchesSubjects(policySpec.Requesters, reqSpec.Subject, reqSpec.Groups) {
+		return false, fmt.Sprintf("subject %s is not an allowed requester", reqSpec.Subject)
+	}
+	if reqSpec.BreakGlass != nil && policySpec.BreakGlass == nil {
+		return false, "the policy does not allow break-glass access"
+	}
+	if reason := checkDuration(policySpec.MaxDuration, RequestDuration(&policySpec, reqSpec.Duration)); reason != "" {
+		return false, reason
+	}
+	if reason := checkPermissions(policySpec.AllowedPermissions, reqSpec.Permissions); reason != "" {
+		return false, reason
+	}
+	if !matchesRoles(policySpec.AllowedRoles, reqSpec.Role) {
+		return false, fmt.Sprintf("role %s %s is not allowed", reqSpec.Role.Kind, reqSpec.Role.Name)
+	}
+	if reason := checkGrantTarget(policySpec.AllowedGrantTargets, reqSpec.GrantTarget, reqSpec.Subject, reqSpec.Groups, rc.req.GetNamespace()); reason != "" {
+		return false, reason
+	}
+	if reason := checkDenied(policySpec.DeniedRoles, policySpec.DeniedPermissions, reqSpec.Role, reqSpec.Permissions); reason != "" {
+		return false, reason
+	}
+	if reason := checkDeniedRoleRules(rc, policySpec.DeniedPermissions); reason != "" {
+		return false, reason
+	}
+	if !matchesSchedule(policySpec.Schedule, requestTime(rc.req)) {
+		return false, "the request is outside the policy's schedule"
+	}
+
+	if msg := evaluateConditions(rc, policySpec.Conditions); msg != "" {
+		return false, msg
+	}
+
+	return true, ""
+}
+
+// checkDuration explains why the requested duration isn't allowed, or returns
+// an empty string when it is
+func checkDuration(policyMaxDuration, requestDuration string) string {
+	if _, err := time.ParseDuration(requestDuration); err != nil {
+		return fmt.Sprintf("duration %q is invalid", requestDuration)
+	}
+	if _, err := time.ParseDuration(policyMaxDuration); err != nil {
+		return fmt.Sprintf("maxDuration %q is invalid", policyMaxDuration)
+	}
+	if !matchesDuration(policyMaxDuration, requestDuration) {
+		return fmt.Sprintf("duration %s exceeds maxDuration %s", requestDuration, policyMaxDuration)
+	}
+	return ""
+}
+
+// checkPermissions names the first requested rule that isn't allowed, or
+// returns an empty string when they all are
+func checkPermissions(allowedRules, requestedRules []rbacv1.PolicyRule) string {
+	for i, rule := range requestedRules {
+		if !matchesPermissions(allowedRules, []rbacv1.PolicyRule{rule}) {
+			return fmt.Sprintf("permission rule %d (%s) is not allowed", i, describeRule(rule))
+		}
+	}
+	return ""
+}
+
+// describeRule summarizes a rule, e.g. "delete on pods" or "get on /healthz"
+func describeRule(rule rbacv1.PolicyRule) string {
+	targets := rule.NonResourceURLs
+	if len(rule.Resources) > 0 {
+		targets = make([]string, 0, len(rule.Resources))
+		for _, resource := range rule.Resources {
+			for _, group := range rule.APIGroups {
+				if group != "" {
+					resource = fmt.Sprintf("%s.%s", resource, group)
+					break
+				}
+			}
+			targets = append(targets, resource)
+		}
+	}
+	return fmt.Sprintf("%s on %s", strings.Join(rule.Verbs, ","), strings.Join(targets, ","))
+}
+
+// requestTime returns the time the request was made, or the time scheduled
+// requests start at. Requests that have not been persisted yet (e.g. during
+// admission) are treated as being made now.
+func requestTime(req common.AccessRequestObject) time.Time {
+	if startAt := req.GetSpec().StartAt; startAt != nil {
+		return startAt.Time
+	}
+	created := req.GetCreationTimestamp()
+	if created.IsZero() {
+		return time.Now()
+	}
+	return created.Time
 }
 
 func matchesSubjects(
@@ -108,10 +398,37 @@ func hasWildcard(slice []string) bool {
 	return slices.Contains(slice, "*")
 }
 
+// patternsAllow is like fieldAllows, but the allowed values can be glob
+// patterns such as "app-*" or "*/scale"
+func patternsAllow(requested, allowed []string) bool {
+	if hasWildcard(allowed) {
+		return true
+	}
+	for _, value := range requested {
+		if !slices.ContainsFunc(allowed, func(pattern string) bool { return globMatch(pattern, value) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceNamesAllow checks the requested resource names against the allowed
+// ones. An empty list stands for every name, so it's only allowed by an empty
+// list or a wildcard.
+func resourceNamesAllow(requested, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	if len(requested) == 0 {
+		return hasWildcard(allowed)
+	}
+	return patternsAllow(requested, allowed)
+}
+
 func policyRuleIsSubsetWithWildcard(requested, allowed rbacv1.PolicyRule) bool {
 	return fieldAllows(requested.APIGroups, allowed.APIGroups) &&
-		fieldAllows(requested.Resources, allowed.Resources) &&
-		fieldAllows(requested.ResourceNames, allowed.ResourceNames) &&
+		patternsAllow(requested.Resources, allowed.Resources) &&
+		resourceNamesAllow(requested.ResourceNames, allowed.ResourceNames) &&
 		fieldAllows(requested.Verbs, allowed.Verbs) &&
 		fieldAllows(requested.NonResourceURLs, allowed.NonResourceURLs)
 }
@@ -0,0 +1,322 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveNamespaceSelector(t *testing.T) {
+	ctx := context.Background()
+
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+	if err := accessv1alpha1.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add access scheme: %v", err)
+	}
+
+	tenantNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tier": "tenant"}}}
+	systemNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}
+
+	fakeClient := ctrlclient.NewClientBuilder().WithScheme(sch).
+		WithObjects(tenantNs, systemNs).
+		Build()
+
+	subjectPolicy := func(priority int) accessv1alpha1.SubjectPolicy {
+		return accessv1alpha1.SubjectPolicy{
+			Priority:     priority,
+			MaxDuration:  "1h",
+			AllowedRoles: []rbacv1.RoleRef{{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"}},
+		}
+	}
+
+	tenantPolicy := &accessv1alpha1.ClusterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+		Spec: accessv1alpha1.ClusterAccessPolicySpec{
+			SubjectPolicy:     subjectPolicy(0),
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "tenant"}},
+		},
+	}
+	clusterPolicy := &accessv1alpha1.ClusterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       accessv1alpha1.ClusterAccessPolicySpec{SubjectPolicy: subjectPolicy(0)},
+	}
+	localPolicy := &accessv1alpha1.AccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "tenant-a"},
+		Spec:       accessv1alpha1.AccessPolicySpec{SubjectPolicy: subjectPolicy(10)},
+	}
+
+	clusterPolicies := NewPolicyManager()
+	clusterPolicies.Update([]common.AccessPolicyObject{tenantPolicy, clusterPolicy})
+
+	resolver := &PolicyResolver{ClusterPolicies: clusterPolicies, Client: fakeClient}
+
+	request := func(namespace string) *accessv1alpha1.AccessRequest {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: namespace},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:  "alice",
+					Duration: "30m",
+					Role:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        common.AccessRequestObject
+		namespaced []common.AccessPolicyObject
+		want       string
+	}{
+		{
+			name: "selector matches namespace labels",
+			req:  request("tenant-a"),
+			want: "tenants",
+		},
+		{
+			name: "selector doesn't match namespace labels",
+			req:  request("kube-system"),
+			want: "",
+		},
+		{
+			name:       "higher priority namespaced policy wins",
+			req:        request("tenant-a"),
+			namespaced: []common.AccessPolicyObject{localPolicy},
+			want:       "local",
+		},
+		{
+			name: "selector policies don't apply to cluster requests",
+			req: &accessv1alpha1.ClusterAccessRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "req"},
+				Spec: accessv1alpha1.ClusterAccessRequestSpec{
+					AccessRequestBaseSpec: request("").Spec.AccessRequestBaseSpec,
+				},
+			},
+			namespaced: clusterPolicies.GetSnapshot(),
+			want:       "cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched := resolver.Resolve(ctx, tt.req, tt.namespaced)
+
+			got := ""
+			if matched != nil {
+				got = matched.GetName()
+			}
+			if got != tt.want {
+				t.Errorf("got policy %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWithTrace(t *testing.T) {
+	ctx := context.Background()
+	resolver := &PolicyResolver{}
+
+	pods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}
+	deletePods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"delete"}}
+	deployments := rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get"}}
+
+	policy := func(name string, priority int, requester, duration string, rules ...rbacv1.PolicyRule) common.AccessPolicyObject {
+		return &accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessPolicySpec{
+				SubjectPolicy: accessv1alpha1.SubjectPolicy{
+					Priority:           priority,
+					Requesters:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: requester}},
+					MaxDuration:        duration,
+					AllowedPermissions: rules,
+				},
+			},
+		}
+	}
+
+	policies := []common.AccessPolicyObject{
+		policy("others", 4, "bob", "8h", pods),
+		policy("short", 3, "alice", "1h", pods, deletePods),
+		policy("read-only", 2, "alice", "4h", pods),
+		policy("deployments", 1, "alice", "4h", pods, deletePods, deployments),
+	}
+
+	request := func(rules ...rbacv1.PolicyRule) common.AccessRequestObject {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:     "alice",
+					Duration:    "2h",
+					Permissions: rules,
+				},
+			},
+		}
+	}
+
+	matched, trace := resolver.ResolveWithTrace(ctx, request(pods, deletePods), policies)
+	if matched == nil || matched.GetName() != "deployments" {
+		t.Fatalf("expected the deployments policy to match, got %v", matched)
+	}
+
+	want := []string{
+		"policy others: subject alice is not an allowed requester",
+		"policy short: duration 2h exceeds maxDuration 1h",
+		"policy read-only: permission rule 1 (delete on pods) is not allowed",
+	}
+	reasons := trace.Reasons()
+	if len(reasons) != len(want) {
+		t.Fatalf("Reasons() = %v, want %v", reasons, want)
+	}
+	for i := range want {
+		if reasons[i] != want[i] {
+			t.Errorf("Reasons()[%d] = %q, want %q", i, reasons[i], want[i])
+		}
+	}
+	if last := trace[len(trace)-1]; !last.Matched || last.Policy != "deployments" {
+		t.Errorf("expected the trace to end with the matched policy, got %+v", last)
+	}
+
+	matched, trace = resolver.ResolveWithTrace(ctx, request(rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"delete"}}), policies)
+	if matched != nil {
+		t.Fatalf("expected no policy to match, got %s", matched.GetName())
+	}
+	if got := trace.Reasons()[3]; got != "policy deployments: permission rule 0 (delete on deployments.apps) is not allowed" {
+		t.Errorf("unexpected reason %q", got)
+	}
+}
+
+func TestResolveDelegated(t *testing.T) {
+	ctx := context.Background()
+	resolver := &PolicyResolver{}
+
+	pods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+	policies := []common.AccessPolicyObject{
+		&accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "delegated", Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessPolicySpec{
+				SubjectPolicy: accessv1alpha1.SubjectPolicy{
+					Requesters:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}, {Kind: rbacv1.GroupKind, Name: "support"}},
+					AllowDelegation:    []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "support"}},
+					MaxDuration:        "1h",
+					AllowedPermissions: []rbacv1.PolicyRule{pods},
+				},
+			},
+		},
+	}
+
+	// Delegated requests only carry the delegator's groups
+	request := func(subject, delegator string, groups ...string) common.AccessRequestObject {
+		req := &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:     subject,
+					Delegator:   delegator,
+					Duration:    "1h",
+					Permissions: []rbacv1.PolicyRule{pods},
+				},
+			},
+		}
+		if delegator != "" {
+			req.Spec.DelegatorGroups = groups
+		} else {
+			req.Spec.Groups = groups
+		}
+		return req
+	}
+	breakGlass := func(obj common.AccessRequestObject) common.AccessRequestObject {
+		obj.GetSpec().BreakGlass = &accessv1alpha1.BreakGlassRequest{Incident: "INC-1"}
+		return obj
+	}
+
+	tests := []struct {
+		name       string
+		request    common.AccessRequestObject
+		wantReason string
+	}{
+		{name: "requester", request: request("alice", "")},
+		{name: "requester in an allowed group", request: request("bob", "", "support")},
+		{name: "delegator in an allowed group", request: request("alice", "carol", "support")},
+		{name: "delegator outside the allowed groups", request: request("alice", "carol", "developers"), wantReason: "carol is not allowed to request access on behalf of alice"},
+		{name: "delegator for a subject who isn't a requester", request: request("bob", "carol", "support"), wantReason: "subject bob is not an allowed requester"},
+		{name: "requester delegating", request: request("bob", "alice"), wantReason: "alice is not allowed to request access on behalf of bob"},
+		{name: "break-glass without a break-glass policy", request: breakGlass(request("alice", "")), wantReason: "the policy does not allow break-glass access"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, trace := resolver.ResolveWithTrace(ctx, tt.request, policies)
+			if tt.wantReason == "" {
+				if matched == nil {
+					t.Fatalf("expected the request to match, got %v", trace.Reasons())
+				}
+				return
+			}
+			if matched != nil {
+				t.Fatalf("expected the request not to match")
+			}
+			if reasons := trace.Reasons(); len(reasons) != 1 || !strings.Contains(reasons[0], tt.wantReason) {
+				t.Errorf("Reasons() = %v, want a reason containing %q", reasons, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestResolveScheduled(t *testing.T) {
+	ctx := context.Background()
+	resolver := &PolicyResolver{}
+
+	pods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+	policies := []common.AccessPolicyObject{
+		&accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "weekend-maintenance", Namespace: "team-a"},
+			Spec: accessv1alpha1.AccessPolicySpec{
+				SubjectPolicy: accessv1alpha1.SubjectPolicy{
+					Requesters:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+					MaxDuration:        "8h",
+					AllowedPermissions: []rbacv1.PolicyRule{pods},
+					Schedule:           &accessv1alpha1.AccessWindow{Weekdays: []accessv1alpha1.Weekday{"Saturday", "Sunday"}},
+				},
+			},
+		},
+	}
+
+	thursday := metav1.NewTime(time.Date(2025, 6, 12, 14, 0, 0, 0, time.UTC))
+	saturday := metav1.NewTime(time.Date(2025, 6, 14, 22, 0, 0, 0, time.UTC))
+
+	request := func(startAt *metav1.Time) common.AccessRequestObject {
+		return &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a", CreationTimestamp: thursday},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:     "alice",
+					Duration:    "4h",
+					Permissions: []rbacv1.PolicyRule{pods},
+					StartAt:     startAt,
+				},
+			},
+		}
+	}
+
+	if matched, trace := resolver.ResolveWithTrace(ctx, request(&saturday), policies); matched == nil {
+		t.Errorf("expected a request scheduled within the policy's schedule to match, got %v", trace.Reasons())
+	}
+	if matched, _ := resolver.ResolveWithTrace(ctx, request(nil), policies); matched != nil {
+		t.Errorf("expected a request outside the policy's schedule not to match")
+	}
+}
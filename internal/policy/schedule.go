@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+const minutesPerDay = 24 * 60
+
+type minuteRange struct {
+	start int
+	end   int
+}
+
+// WindowEnd reports whether t falls inside the access window and, if so, when
+// the current occurrence of the window ends. Adjacent ranges that run across
+// midnight are treated as one occurrence. A zero end time means the window
+// never closes (no window is set, or it covers the whole week).
+func WindowEnd(window *accessv1alpha1.AccessWindow, t time.Time) (time.Time, bool) {
+	if window == nil {
+		return time.Time{}, true
+	}
+
+	loc, ranges, err := parseWindow(window)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	if !weekdayAllowed(window.Weekdays, day.Weekday()) {
+		return time.Time{}, false
+	}
+
+	current, found := findRange(ranges, func(r minuteRange) bool {
+		return r.start <= minute && minute < r.end
+	})
+	if !found {
+		return time.Time{}, false
+	}
+	end := extendRange(ranges, current.end)
+
+	// Follow the window across midnight while the next day continues it
+	for range 7 {
+		if end != minutesPerDay {
+			return atMinute(day, end), true
+		}
+
+		next := day.AddDate(0, 0, 1)
+		if !weekdayAllowed(window.Weekdays, next.Weekday()) {
+			return atMinute(day, end), true
+		}
+
+		first, found := findRange(ranges, func(r minuteRange) bool {
+			return r.start == 0
+		})
+		if !found {
+			return atMinute(day, end), true
+		}
+
+		day = next
+		end = extendRange(ranges, first.end)
+	}
+
+	return time.Time{}, true
+}
+
+func matchesSchedule(window *accessv1alpha1.AccessWindow, t time.Time) bool {
+	_, ok := WindowEnd(window, t)
+	return ok
+}
+
+func parseWindow(window *accessv1alpha1.AccessWindow) (*time.Location, []minuteRange, error) {
+	tz := window.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone %q: %w", tz, err)
+	}
+
+	if len(window.Hours) == 0 {
+		return loc, []minuteRange{{start: 0, end: minutesPerDay}}, nil
+	}
+
+	ranges := make([]minuteRange, 0, len(window.Hours))
+	for _, h := range window.Hours {
+		start, err := parseClock(h.Start)
+		if err != nil {
+			return nil, nil, err
+		}
+		end, err := parseClock(h.End)
+		if err != nil {
+			return nil, nil, err
+		}
+		if start >= end {
+			return nil, nil, fmt.Errorf("hour range %s-%s must start before it ends", h.Start, h.End)
+		}
+		ranges = append(ranges, minuteRange{start: start, end: end})
+	}
+
+	return loc, ranges, nil
+}
+
+func parseClock(s string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+
+	total := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || total > minutesPerDay {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+
+	return total, nil
+}
+
+func findRange(ranges []minuteRange, match func(minuteRange) bool) (minuteRange, bool) {
+	// Prefer the range that runs latest so overlapping ranges extend each other
+	var best minuteRange
+	found := false
+	for _, r := range ranges {
+		if match(r) && (!found || r.end > best.end) {
+			best = r
+			found = true
+		}
+	}
+	return best, found
+}
+
+// extendRange follows ranges that overlap or touch end and returns the
+// furthest point they reach.
+func extendRange(ranges []minuteRange, end int) int {
+	for {
+		next, found := findRange(ranges, func(r minuteRange) bool {
+			return r.start <= end && r.end > end
+		})
+		if !found {
+			return end
+		}
+		end = next.end
+	}
+}
+
+func weekdayAllowed(weekdays []accessv1alpha1.Weekday, day time.Weekday) bool {
+	if len(weekdays) == 0 {
+		return true
+	}
+	return slices.Contains(weekdays, accessv1alpha1.Weekday(day.String()))
+}
+
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
@@ -0,0 +1,98 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+)
+
+func TestWindowEnd(t *testing.T) {
+	businessHours := &accessv1alpha1.AccessWindow{
+		Weekdays: []accessv1alpha1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		Hours:    []accessv1alpha1.HourRange{{Start: "09:00", End: "17:00"}},
+		TimeZone: "Europe/London",
+	}
+
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("unable to load time zone: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		window   *accessv1alpha1.AccessWindow
+		at       time.Time
+		inWindow bool
+		end      time.Time
+	}{
+		{
+			name:     "no window",
+			window:   nil,
+			at:       time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC),
+			inWindow: true,
+		},
+		{
+			name:     "inside business hours",
+			window:   businessHours,
+			at:       time.Date(2025, 6, 2, 10, 30, 0, 0, london), // Monday
+			inWindow: true,
+			end:      time.Date(2025, 6, 2, 17, 0, 0, 0, london),
+		},
+		{
+			name:     "evaluated in the window's time zone",
+			window:   businessHours,
+			at:       time.Date(2025, 6, 2, 16, 30, 0, 0, time.UTC), // 17:30 in London
+			inWindow: false,
+		},
+		{
+			name:     "weekend",
+			window:   businessHours,
+			at:       time.Date(2025, 6, 7, 10, 0, 0, 0, london), // Saturday
+			inWindow: false,
+		},
+		{
+			name: "overlapping ranges are merged",
+			window: &accessv1alpha1.AccessWindow{
+				Hours: []accessv1alpha1.HourRange{{Start: "08:00", End: "12:00"}, {Start: "11:00", End: "18:00"}},
+			},
+			at:       time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+			inWindow: true,
+			end:      time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "window continues across midnight",
+			window: &accessv1alpha1.AccessWindow{
+				Weekdays: []accessv1alpha1.Weekday{"Friday", "Saturday"},
+				Hours:    []accessv1alpha1.HourRange{{Start: "00:00", End: "06:00"}, {Start: "22:00", End: "24:00"}},
+			},
+			at:       time.Date(2025, 6, 6, 23, 0, 0, 0, time.UTC), // Friday
+			inWindow: true,
+			end:      time.Date(2025, 6, 7, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "whole week never closes",
+			window:   &accessv1alpha1.AccessWindow{},
+			at:       time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+			inWindow: true,
+		},
+		{
+			name:     "invalid time zone is rejected",
+			window:   &accessv1alpha1.AccessWindow{TimeZone: "Mars/Olympus_Mons"},
+			at:       time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+			inWindow: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, inWindow := WindowEnd(tt.window, tt.at)
+			if inWindow != tt.inWindow {
+				t.Fatalf("got inWindow %v, want %v", inWindow, tt.inWindow)
+			}
+			if !end.Equal(tt.end) {
+				t.Errorf("got end %v, want %v", end, tt.end)
+			}
+		})
+	}
+}
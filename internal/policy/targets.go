@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// checkGrantTarget explains why the request's grant target isn't allowed, or
+// returns an empty string when it is. Requests without a target are granted
+// to the subject.
+func checkGrantTarget(
+	allowedTargets []rbacv1.Subject,
+	target *rbacv1.Subject,
+	subject string,
+	groups []string,
+	namespace string,
+) string {
+	if target == nil {
+		return ""
+	}
+
+	switch target.Kind {
+	case rbacv1.UserKind:
+		if target.Name != subject {
+			return fmt.Sprintf("grant target User %s is not the subject", target.Name)
+		}
+		return ""
+	case rbacv1.GroupKind:
+		if !slices.Contains(groups, target.Name) {
+			return fmt.Sprintf("subject %s is not a member of grant target Group %s", subject, target.Name)
+		}
+	case rbacv1.ServiceAccountKind:
+		if target.Namespace == "" {
+			return fmt.Sprintf("grant target ServiceAccount %s has no namespace", target.Name)
+		}
+	default:
+		return fmt.Sprintf("grant target %s %s has an unsupported kind", target.Kind, target.Name)
+	}
+
+	for _, allowed := range allowedTargets {
+		if allowed.Kind != target.Kind || !globMatch(allowed.Name, target.Name) {
+			continue
+		}
+		if target.Kind == rbacv1.ServiceAccountKind {
+			allowedNamespace := allowed.Namespace
+			if allowedNamespace == "" {
+				allowedNamespace = namespace
+			}
+			if allowedNamespace != target.Namespace {
+				continue
+			}
+		}
+		return ""
+	}
+
+	return fmt.Sprintf("grant target %s is not allowed", describeSubject(*target))
+}
+
+// GrantSubject returns the subject bound by a grant: its target, or the
+// requesting user when the grant has no target
+func GrantSubject(target *rbacv1.Subject, user string) rbacv1.Subject {
+	if target == nil {
+		return rbacv1.Subject{Kind: rbacv1.UserKind, Name: user, APIGroup: rbacv1.GroupName}
+	}
+
+	subject := *target
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		subject.APIGroup = ""
+	} else {
+		subject.APIGroup = rbacv1.GroupName
+	}
+	return subject
+}
+
+func describeSubject(subject rbacv1.Subject) string {
+	if subject.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", subject.Kind, subject.Namespace, subject.Name)
+	}
+	return fmt.Sprintf("%s %s", subject.Kind, subject.Name)
+}
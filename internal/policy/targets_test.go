@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestCheckGrantTarget(t *testing.T) {
+	allowed := []rbacv1.Subject{
+		{Kind: rbacv1.GroupKind, Name: "oncall-*"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "release-*", Namespace: "ci"},
+	}
+	groups := []string{"developers", "oncall-payments"}
+
+	group := func(name string) *rbacv1.Subject { return &rbacv1.Subject{Kind: rbacv1.GroupKind, Name: name} }
+	serviceAccount := func(namespace, name string) *rbacv1.Subject {
+		return &rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: name}
+	}
+
+	tests := []struct {
+		name       string
+		target     *rbacv1.Subject
+		wantReason string
+	}{
+		{name: "no target", target: nil},
+		{name: "the subject", target: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}},
+		{name: "another user", target: &rbacv1.Subject{Kind: rbacv1.UserKind, Name: "bob"}, wantReason: "is not the subject"},
+		{name: "allowed group", target: group("oncall-payments")},
+		{name: "group the subject isn't in", target: group("oncall-billing"), wantReason: "is not a member"},
+		{name: "group that isn't allowed", target: group("developers"), wantReason: "grant target Group developers is not allowed"},
+		{name: "service account in the request's namespace", target: serviceAccount("team-a", "deployer")},
+		{name: "service account in another namespace", target: serviceAccount("team-b", "deployer"), wantReason: "is not allowed"},
+		{name: "service account in an allowed namespace", target: serviceAccount("ci", "release-bot")},
+		{name: "service account without a namespace", target: serviceAccount("", "deployer"), wantReason: "has no namespace"},
+		{name: "unsupported kind", target: &rbacv1.Subject{Kind: "Node", Name: "node-1"}, wantReason: "unsupported kind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := checkGrantTarget(allowed, tt.target, "alice", groups, "team-a")
+			if tt.wantReason == "" && reason != "" {
+				t.Errorf("expected the target to be allowed, got %q", reason)
+			}
+			if tt.wantReason != "" && !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("expected a reason containing %q, got %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestGrantSubject(t *testing.T) {
+	if got := GrantSubject(nil, "alice"); got.Kind != rbacv1.UserKind || got.Name != "alice" || got.APIGroup != rbacv1.GroupName {
+		t.Errorf("unexpected subject for a grant without a target: %+v", got)
+	}
+
+	sa := GrantSubject(&rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, APIGroup: rbacv1.GroupName, Namespace: "ci", Name: "deployer"}, "alice")
+	if sa.APIGroup != "" || sa.Namespace != "ci" || sa.Name != "deployer" {
+		t.Errorf("unexpected subject for a ServiceAccount target: %+v", sa)
+	}
+
+	group := GrantSubject(&rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "oncall"}, "alice")
+	if group.APIGroup != rbacv1.GroupName || group.Name != "oncall" {
+		t.Errorf("unexpected subject for a Group target: %+v", group)
+	}
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+)
+
+// templatedPolicy is a policy with the fields of its template applied
+type templatedPolicy struct {
+	common.AccessPolicyObject
+	policy accessv1alpha1.SubjectPolicy
+}
+
+func (p *templatedPolicy) GetPolicy() accessv1alpha1.SubjectPolicy {
+	return p.policy
+}
+
+// applyTemplate returns the policy with the fields of its template applied.
+// Policies without a template, or referencing a template that doesn't exist,
+// are returned unchanged.
+func applyTemplate(
+	policy common.AccessPolicyObject,
+	templates map[string]accessv1alpha1.AccessPolicyTemplateSpec,
+) common.AccessPolicyObject {
+	spec := policy.GetPolicy()
+	if spec.Template == "" {
+		return policy
+	}
+
+	tmpl, ok := templates[spec.Template]
+	if !ok {
+		return policy
+	}
+
+	return &templatedPolicy{
+		AccessPolicyObject: policy,
+		policy:             MergeTemplate(spec, tmpl),
+	}
+}
+
+// MergeTemplate fills the fields the policy doesn't set from the template
+func MergeTemplate(
+	spec accessv1alpha1.SubjectPolicy,
+	tmpl accessv1alpha1.AccessPolicyTemplateSpec,
+) accessv1alpha1.SubjectPolicy {
+	merged := *spec.DeepCopy()
+
+	if len(merged.AllowedRoles) == 0 {
+		merged.AllowedRoles = tmpl.AllowedRoles
+	}
+	if len(merged.AllowedPermissions) == 0 {
+		merged.AllowedPermissions = tmpl.AllowedPermissions
+	}
+	if merged.MaxDuration == "" {
+		merged.MaxDuration = tmpl.MaxDuration
+	}
+	if len(merged.Approvers) == 0 {
+		merged.Approvers = tmpl.Approvers
+	}
+
+	return merged
+}
@@ -0,0 +1,374 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Condition types reported on policies
+const (
+	ConditionReady               = "Ready"
+	ConditionDurationValid       = "DurationValid"
+	ConditionRolesExist          = "RolesExist"
+	ConditionApproversConfigured = "ApproversConfigured"
+	ConditionTemplateResolved    = "TemplateResolved"
+	ConditionShadowed            = "Shadowed"
+)
+
+// ValidatePolicy checks the policy's configuration and returns a condition for
+// each check. The policy should have its template applied, templateExists
+// tells whether the template it references was found.
+func ValidatePolicy(
+	ctx context.Context,
+	c client.Reader,
+	policy common.AccessPolicyObject,
+	templateExists bool,
+) ([]metav1.Condition, error) {
+	spec := policy.GetPolicy()
+	conditions := []metav1.Condition{}
+
+	if spec.Template != "" {
+		if templateExists {
+			conditions = append(conditions, condition(ConditionTemplateResolved, true, "TemplateFound", fmt.Sprintf("template %s was applied", spec.Template)))
+		} else {
+			conditions = append(conditions, condition(ConditionTemplateResolved, false, "TemplateNotFound", fmt.Sprintf("template %s does not exist", spec.Template)))
+		}
+	}
+
+	if spec.MaxDuration == "" {
+		conditions = append(conditions, condition(ConditionDurationValid, false, "InvalidDuration", "maxDuration is not set"))
+	} else if _, err := time.ParseDuration(spec.MaxDuration); err != nil {
+		conditions = append(conditions, condition(ConditionDurationValid, false, "InvalidDuration", fmt.Sprintf("maxDuration %q is invalid: %s", spec.MaxDuration, err)))
+	} else if problem := checkDefaultDurations(spec); problem != "" {
+		conditions = append(conditions, condition(ConditionDurationValid, false, "InvalidDuration", problem))
+	} else {
+		conditions = append(conditions, condition(ConditionDurationValid, true, "ValidDuration", fmt.Sprintf("maxDuration is %s", spec.MaxDuration)))
+	}
+
+	missing, err := MissingRoles(ctx, c, policy.GetNamespace(), spec.AllowedRoles)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		conditions = append(conditions, condition(ConditionRolesExist, false, "RoleNotFound", fmt.Sprintf("allowed roles do not exist: %s", strings.Join(missing, ", "))))
+	} else {
+		conditions = append(conditions, condition(ConditionRolesExist, true, "RolesFound", "all allowed roles exist"))
+	}
+
+	if spec.RequiredApprovals > 0 && len(spec.Approvers) == 0 && len(spec.ApprovalStages) == 0 && len(spec.ApprovalQuorum) == 0 {
+		conditions = append(conditions, condition(ConditionApproversConfigured, false, "NoApprovers", fmt.Sprintf("the policy requires %d approvals but has no approvers", spec.RequiredApprovals)))
+	} else {
+		conditions = append(conditions, condition(ConditionApproversConfigured, true, "ApproversFound", "the policy's approvals can be satisfied"))
+	}
+
+	return conditions, nil
+}
+
+// MissingRoles returns the allowed roles that don't exist. Roles are looked up
+// in the policy's namespace, and aren't checked for cluster policies.
+func MissingRoles(ctx context.Context, c client.Reader, namespace string, roles []rbacv1.RoleRef) ([]string, error) {
+	var missing []string
+
+	for _, ref := range roles {
+		var obj client.Object
+		var key client.ObjectKey
+
+		switch ref.Kind {
+		case common.RoleKindCluster:
+			obj, key = &rbacv1.ClusterRole{}, client.ObjectKey{Name: ref.Name}
+		case common.RoleKindRole:
+			if namespace == "" {
+				continue
+			}
+			obj, key = &rbacv1.Role{}, client.ObjectKey{Namespace: namespace, Name: ref.Name}
+		default:
+			missing = append(missing, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))
+			continue
+		}
+
+		if err := c.Get(ctx, key, obj); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to fetch %s %s: %w", ref.Kind, ref.Name, err)
+			}
+			missing = append(missing, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))
+		}
+	}
+
+	return missing, nil
+}
+
+// ShadowedBy returns the policy evaluated before the given policy that matches
+// every request the policy matches, or nil when the policy can match requests.
+// The snapshot must be in evaluation order.
+func ShadowedBy(policy common.AccessPolicyObject, snapshot []common.AccessPolicyObject) common.AccessPolicyObject {
+	for _, other := range snapshot {
+		if other.GetName() == policy.GetName() && other.GetNamespace() == policy.GetNamespace() {
+			return nil
+		}
+
+		if other.GetNamespace() != policy.GetNamespace() ||
+			!reflect.DeepEqual(other.GetNamespaceSelector(), policy.GetNamespaceSelector()) {
+			continue
+		}
+
+		if covers(other.GetPolicy(), policy.GetPolicy()) {
+			return other
+		}
+	}
+
+	return nil
+}
+
+// covers returns true if every request matching q also matches p
+func covers(p, q accessv1alpha1.SubjectPolicy) bool {
+	// Policies with further restrictions might not match
+	if p.Schedule != nil || len(p.Conditions) > 0 || len(p.DeniedRoles) > 0 || len(p.DeniedPermissions) > 0 ||
+		len(q.AllowedGrantTargets) > 0 || len(q.AllowDelegation) > 0 || q.BreakGlass != nil && p.BreakGlass == nil {
+		return false
+	}
+
+	if len(p.Requesters) > 0 {
+		if len(q.Requesters) == 0 {
+			return false
+		}
+		for _, requester := range q.Requesters {
+			if !subjectListed(p.Requesters, requester) {
+				return false
+			}
+		}
+	}
+
+	pDuration, err := time.ParseDuration(p.MaxDuration)
+	if err != nil {
+		return false
+	}
+	qDuration, err := time.ParseDuration(q.MaxDuration)
+	if err != nil || qDuration > pDuration {
+		return false
+	}
+
+	for _, role := range q.AllowedRoles {
+		if !matchesRoles(p.AllowedRoles, role) {
+			return false
+		}
+	}
+
+	return matchesPermissions(p.AllowedPermissions, q.AllowedPermissions)
+}
+
+func subjectListed(subjects []rbacv1.Subject, subject rbacv1.Subject) bool {
+	for _, s := range subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func condition(conditionType string, ok bool, reason, message string) metav1.Condition {
+	status := metav1.ConditionTrue
+	if !ok {
+		status = metav1.ConditionFalse
+	}
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// escalatingVerbs are the verbs allowing a subject to gain permissions beyond
+// the ones granted to them
+var escalatingVerbs = []string{"escalate", "bind", "impersonate"}
+
+// CheckPolicySpec returns the problems making the policy invalid, and warnings
+// for configurations that are valid but risky.
+func CheckPolicySpec(spec accessv1alpha1.SubjectPolicy, clusterScoped bool) (problems []string, warnings []string) {
+	if spec.MaxDuration != "" {
+		if _, err := time.ParseDuration(spec.MaxDuration); err != nil {
+			problems = append(problems, fmt.Sprintf("maxDuration %q is invalid: %s", spec.MaxDuration, err))
+		}
+	}
+	if problem := checkDefaultDurations(spec); problem != "" {
+		problems = append(problems, problem)
+	}
+
+	problems = append(problems, checkSubjectKinds("requesters", spec.Requesters)...)
+	problems = append(problems, checkSubjectKinds("approvers", spec.Approvers)...)
+	problems = append(problems, checkSubjectKinds("allowDelegation", spec.AllowDelegation)...)
+	for _, stage := range spec.ApprovalStages {
+		problems = append(problems, checkSubjectKinds(fmt.Sprintf("approval stage %s", stage.Name), stage.Approvers)...)
+	}
+
+	for _, target := range spec.AllowedGrantTargets {
+		if target.Kind != rbacv1.GroupKind && target.Kind != rbacv1.ServiceAccountKind {
+			problems = append(problems, fmt.Sprintf("allowedGrantTargets: %s has unsupported kind %q, only Group and ServiceAccount are supported", target.Name, target.Kind))
+		}
+	}
+
+	if len(spec.ApprovalStages) == 0 {
+		if problem := checkApproverCount("approvers", spec.Approvers, spec.RequiredApprovals); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	for _, stage := range spec.ApprovalStages {
+		if problem := checkApproverCount(fmt.Sprintf("approval stage %s", stage.Name), stage.Approvers, stage.RequiredApprovals); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	if spec.Extensions != nil {
+		problems = append(problems, checkExtensions(spec)...)
+	}
+	if spec.BreakGlass != nil {
+		problems = append(problems, checkSubjectKinds("breakGlass reviewers", spec.BreakGlass.Reviewers)...)
+		if len(BreakGlassReviewers(&spec)) == 0 {
+			problems = append(problems, "breakGlass: the policy has no approvers to review break-glass requests, set breakGlass reviewers")
+		}
+	}
+
+	for _, role := range spec.AllowedRoles {
+		switch role.Kind {
+		case common.RoleKindCluster:
+			if !clusterScoped {
+				problems = append(problems, fmt.Sprintf("ClusterRole %s can't be granted by a namespaced policy, only Roles can", role.Name))
+			}
+			if role.Name == "cluster-admin" {
+				warnings = append(warnings, "the policy allows requesting cluster-admin")
+			}
+		case common.RoleKindRole:
+			if clusterScoped {
+				problems = append(problems, fmt.Sprintf("Role %s can't be granted by a cluster policy, only ClusterRoles can", role.Name))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("role %s has unsupported kind %q", role.Name, role.Kind))
+		}
+	}
+
+	for i, rule := range spec.AllowedPermissions {
+		for _, verb := range escalatingVerbs {
+			if slices.Contains(rule.Verbs, verb) {
+				problems = append(problems, fmt.Sprintf("allowedPermissions[%d] allows the %s verb", i, verb))
+			}
+		}
+		if hasWildcard(rule.Verbs) {
+			warnings = append(warnings, fmt.Sprintf("allowedPermissions[%d] allows all verbs, including %s", i, strings.Join(escalatingVerbs, ", ")))
+		}
+		if hasWildcard(rule.APIGroups) || hasWildcard(rule.Resources) {
+			warnings = append(warnings, fmt.Sprintf("allowedPermissions[%d] allows all resources of its API groups", i))
+		}
+	}
+
+	for _, cond := range spec.Conditions {
+		if _, err := CompileCondition(cond.Expression); err != nil {
+			problems = append(problems, fmt.Sprintf("condition %q is invalid: %s", cond.Expression, err))
+		}
+	}
+	for _, rule := range spec.AutoApprovals {
+		for _, cond := range rule.Conditions {
+			if _, err := CompileCondition(cond.Expression); err != nil {
+				problems = append(problems, fmt.Sprintf("auto-approval rule %s condition %q is invalid: %s", rule.Name, cond.Expression, err))
+			}
+		}
+	}
+
+	if spec.RequiredApprovals == 0 && len(spec.ApprovalStages) == 0 && len(spec.ApprovalQuorum) == 0 {
+		warnings = append(warnings, "requests are approved without review since requiredApprovals is 0")
+	}
+	if spec.AllowSelfApproval {
+		warnings = append(warnings, "requesters can approve their own requests")
+	}
+
+	return problems, warnings
+}
+
+// checkSubjectKinds returns a problem for each subject of a kind the policy
+// can't match
+func checkSubjectKinds(field string, subjects []rbacv1.Subject) []string {
+	var problems []string
+	for _, subject := range subjects {
+		if subject.Kind != rbacv1.UserKind && subject.Kind != rbacv1.GroupKind {
+			problems = append(problems, fmt.Sprintf("%s: %s has unsupported kind %q, only User and Group are supported", field, subject.Name, subject.Kind))
+		}
+	}
+	return problems
+}
+
+// checkApproverCount returns a problem if the approvers are all users and
+// there are fewer of them than the required approvals
+func checkApproverCount(field string, approvers []rbacv1.Subject, required int) string {
+	if len(approvers) == 0 {
+		return ""
+	}
+
+	users := 0
+	for _, approver := range approvers {
+		switch approver.Kind {
+		case rbacv1.GroupKind:
+			return ""
+		case rbacv1.UserKind:
+			users++
+		}
+	}
+
+	if users < required {
+		return fmt.Sprintf("%s: %d approvals are required but only %d users can approve", field, required, users)
+	}
+	return ""
+}
+
+// checkDefaultDurations explains why the policy's defaultDuration or
+// pendingTimeout is invalid, or returns an empty string when they're valid.
+// The default duration can't exceed the policy's maxDuration.
+func checkDefaultDurations(spec accessv1alpha1.SubjectPolicy) string {
+	if spec.PendingTimeout != "" {
+		if _, err := time.ParseDuration(spec.PendingTimeout); err != nil {
+			return fmt.Sprintf("pendingTimeout %q is invalid: %s", spec.PendingTimeout, err)
+		}
+	}
+	if spec.DefaultDuration == "" {
+		return ""
+	}
+	defaultDuration, err := time.ParseDuration(spec.DefaultDuration)
+	if err != nil {
+		return fmt.Sprintf("defaultDuration %q is invalid: %s", spec.DefaultDuration, err)
+	}
+	if maxDuration, err := time.ParseDuration(spec.MaxDuration); err == nil && defaultDuration > maxDuration {
+		return fmt.Sprintf("defaultDuration %s exceeds maxDuration %s", spec.DefaultDuration, spec.MaxDuration)
+	}
+	return ""
+}
+
+// checkExtensions returns the problems with the policy's extension rules. A
+// grant lasts at least maxDuration, so maxTotalDuration can't be shorter.
+func checkExtensions(spec accessv1alpha1.SubjectPolicy) []string {
+	var problems []string
+	extensions := spec.Extensions
+
+	if maxTotal, err := time.ParseDuration(extensions.MaxTotalDuration); err != nil {
+		problems = append(problems, fmt.Sprintf("extensions: maxTotalDuration %q is invalid: %s", extensions.MaxTotalDuration, err))
+	} else if maxDuration, err := time.ParseDuration(spec.MaxDuration); err == nil && maxTotal < maxDuration {
+		problems = append(problems, fmt.Sprintf("extensions: maxTotalDuration %s is shorter than maxDuration %s", extensions.MaxTotalDuration, spec.MaxDuration))
+	}
+
+	problems = append(problems, checkSubjectKinds("extensions approvers", extensions.Approvers)...)
+	if len(extensions.Approvers) > 0 {
+		if problem := checkApproverCount("extensions approvers", extensions.Approvers, extensions.RequiredApprovals); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,329 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	view := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}}
+	debug := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "team-a"}}
+	client := ctrlclient.NewClientBuilder().WithScheme(sch).WithObjects(view, debug).Build()
+
+	clusterRole := func(name string) rbacv1.RoleRef {
+		return rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: name}
+	}
+	role := func(name string) rbacv1.RoleRef {
+		return rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: name}
+	}
+	approvers := []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}}
+
+	policy := func(namespace string, spec accessv1alpha1.SubjectPolicy) common.AccessPolicyObject {
+		if namespace == "" {
+			return &accessv1alpha1.ClusterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       accessv1alpha1.ClusterAccessPolicySpec{SubjectPolicy: spec},
+			}
+		}
+		return &accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: namespace},
+			Spec:       accessv1alpha1.AccessPolicySpec{SubjectPolicy: spec},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		policy         common.AccessPolicyObject
+		templateExists bool
+		wantFailed     []string
+	}{
+		{
+			name: "valid policy",
+			policy: policy("team-a", accessv1alpha1.SubjectPolicy{
+				MaxDuration:       "1h",
+				AllowedRoles:      []rbacv1.RoleRef{clusterRole("view"), role("debug")},
+				RequiredApprovals: 1,
+				Approvers:         approvers,
+			}),
+		},
+		{
+			name: "missing roles",
+			policy: policy("team-b", accessv1alpha1.SubjectPolicy{
+				MaxDuration:  "1h",
+				AllowedRoles: []rbacv1.RoleRef{clusterRole("admin"), role("debug")},
+			}),
+			wantFailed: []string{ConditionRolesExist},
+		},
+		{
+			name: "roles aren't checked for cluster policies",
+			policy: policy("", accessv1alpha1.SubjectPolicy{
+				MaxDuration:  "1h",
+				AllowedRoles: []rbacv1.RoleRef{role("debug")},
+			}),
+		},
+		{
+			name:       "missing duration and approvers",
+			policy:     policy("team-a", accessv1alpha1.SubjectPolicy{RequiredApprovals: 2}),
+			wantFailed: []string{ConditionDurationValid, ConditionApproversConfigured},
+		},
+		{
+			name:       "missing template",
+			policy:     policy("team-a", accessv1alpha1.SubjectPolicy{Template: "debug-pods", MaxDuration: "1h"}),
+			wantFailed: []string{ConditionTemplateResolved},
+		},
+		{
+			name:           "resolved template",
+			policy:         policy("team-a", accessv1alpha1.SubjectPolicy{Template: "debug-pods", MaxDuration: "1h"}),
+			templateExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := ValidatePolicy(context.Background(), client, tt.policy, tt.templateExists)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, conditionType := range []string{ConditionDurationValid, ConditionRolesExist, ConditionApproversConfigured} {
+				if meta.FindStatusCondition(conditions, conditionType) == nil {
+					t.Errorf("expected a %s condition", conditionType)
+				}
+			}
+
+			var failed []string
+			for _, c := range conditions {
+				if c.Status != metav1.ConditionTrue {
+					failed = append(failed, c.Type)
+				}
+			}
+			if len(failed) != len(tt.wantFailed) {
+				t.Fatalf("failed conditions = %v, want %v", failed, tt.wantFailed)
+			}
+			for i := range failed {
+				if failed[i] != tt.wantFailed[i] {
+					t.Errorf("failed conditions = %v, want %v", failed, tt.wantFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestShadowedBy(t *testing.T) {
+	pods := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}}
+	podsAndLogs := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list"}}}
+
+	policy := func(name, namespace, duration string, rules []rbacv1.PolicyRule) *accessv1alpha1.AccessPolicy {
+		return &accessv1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: accessv1alpha1.AccessPolicySpec{
+				SubjectPolicy: accessv1alpha1.SubjectPolicy{MaxDuration: duration, AllowedPermissions: rules},
+			},
+		}
+	}
+
+	broad := policy("broad", "team-a", "2h", podsAndLogs)
+	narrow := policy("narrow", "team-a", "1h", pods)
+	longer := policy("longer", "team-a", "4h", pods)
+	other := policy("other", "team-b", "1h", pods)
+
+	scheduled := policy("scheduled", "team-a", "8h", podsAndLogs)
+	scheduled.Spec.Schedule = &accessv1alpha1.AccessWindow{}
+
+	snapshot := []common.AccessPolicyObject{scheduled, broad, narrow, longer, other}
+
+	tests := []struct {
+		policy common.AccessPolicyObject
+		want   string
+	}{
+		{policy: scheduled},
+		{policy: broad},
+		{policy: narrow, want: "broad"},
+		{policy: longer},
+		{policy: other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.GetName(), func(t *testing.T) {
+			got := ""
+			if shadow := ShadowedBy(tt.policy, snapshot); shadow != nil {
+				got = shadow.GetName()
+			}
+			if got != tt.want {
+				t.Errorf("ShadowedBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPolicySpec(t *testing.T) {
+	user := func(name string) rbacv1.Subject { return rbacv1.Subject{Kind: rbacv1.UserKind, Name: name} }
+	group := func(name string) rbacv1.Subject { return rbacv1.Subject{Kind: rbacv1.GroupKind, Name: name} }
+	pods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}
+
+	valid := func() accessv1alpha1.SubjectPolicy {
+		return accessv1alpha1.SubjectPolicy{
+			Requesters:         []rbacv1.Subject{group("developers")},
+			AllowedPermissions: []rbacv1.PolicyRule{pods},
+			MaxDuration:        "1h",
+			RequiredApprovals:  1,
+			Approvers:          []rbacv1.Subject{group("sre")},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(*accessv1alpha1.SubjectPolicy)
+		clusterScoped bool
+		wantProblems  int
+		wantWarnings  int
+	}{
+		{name: "valid policy", modify: func(*accessv1alpha1.SubjectPolicy) {}},
+		{
+			name: "more approvals than user approvers",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.RequiredApprovals = 2
+				p.Approvers = []rbacv1.Subject{user("alice")}
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "group approvers can give several approvals",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.RequiredApprovals = 2
+				p.Approvers = []rbacv1.Subject{user("alice"), group("sre")}
+			},
+		},
+		{
+			name: "stage with too few approvers",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.ApprovalStages = []accessv1alpha1.ApprovalStage{
+					{Name: "security", Approvers: []rbacv1.Subject{user("bob")}, RequiredApprovals: 2},
+				}
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "requester with an unsupported kind",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.Requesters = append(p.Requesters, rbacv1.Subject{Kind: "ServiceAccount", Name: "ci", Namespace: "ci"})
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "escalating verbs",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.AllowedPermissions = []rbacv1.PolicyRule{{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles"}, Verbs: []string{"bind", "escalate"}}}
+			},
+			wantProblems: 2,
+		},
+		{
+			name: "wildcard permissions",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.AllowedPermissions = []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}
+			},
+			wantWarnings: 2,
+		},
+		{
+			name: "role in a cluster policy",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.AllowedRoles = []rbacv1.RoleRef{{Kind: "Role", Name: "debug"}}
+			},
+			clusterScoped: true,
+			wantProblems:  1,
+		},
+		{
+			name: "cluster role in a namespaced policy",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.AllowedRoles = []rbacv1.RoleRef{{Kind: "ClusterRole", Name: "view"}}
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "invalid condition",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.Conditions = []accessv1alpha1.PolicyCondition{{Expression: "request.duration >"}}
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "default duration within maxDuration",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.DefaultDuration = "30m"
+				p.PendingTimeout = "12h"
+			},
+		},
+		{
+			name: "default duration exceeding maxDuration",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.DefaultDuration = "2h"
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "extensions",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.Extensions = &accessv1alpha1.GrantExtensions{MaxTotalDuration: "4h", RequiredApprovals: 1}
+			},
+		},
+		{
+			name: "extensions shorter than maxDuration",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.Extensions = &accessv1alpha1.GrantExtensions{MaxTotalDuration: "30m", RequiredApprovals: 1}
+			},
+			wantProblems: 1,
+		},
+		{
+			name: "break-glass reviewed by the approvers",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.BreakGlass = &accessv1alpha1.BreakGlassPolicy{}
+			},
+		},
+		{
+			name: "break-glass without reviewers",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.RequiredApprovals = 0
+				p.Approvers = nil
+				p.BreakGlass = &accessv1alpha1.BreakGlassPolicy{}
+			},
+			wantProblems: 1,
+			wantWarnings: 1,
+		},
+		{
+			name: "no approvals required",
+			modify: func(p *accessv1alpha1.SubjectPolicy) {
+				p.RequiredApprovals = 0
+			},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid()
+			tt.modify(&spec)
+
+			problems, warnings := CheckPolicySpec(spec, tt.clusterScoped)
+			if len(problems) != tt.wantProblems {
+				t.Errorf("problems = %v, want %d", problems, tt.wantProblems)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	common "github.com/itsthatdude/jit-access-controller/internal/common"
 	"github.com/itsthatdude/jit-access-controller/internal/metrics"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
 )
 
 type GrantProcessor struct {
@@ -55,11 +56,21 @@ func (r *GrantProcessor) ReconcileGrant(ctx context.Context, obj common.AccessGr
 	if !obj.GetDeletionTimestamp().IsZero() {
 		log.Info("handling deletion of grant", "name", obj.GetName())
 
-		err := r.handleExpired(ctx, obj, false)
+		// Retained grants were cleaned up when they expired
+		if !status.ExpiredAt.IsZero() {
+			return ctrl.Result{}, nil
+		}
+
+		err := r.handleExpired(ctx, obj, status, false)
 
 		return ctrl.Result{}, err
 	}
 
+	// Expired grants retained for quota tracking are deleted once the retention period has passed
+	if !status.ExpiredAt.IsZero() {
+		return r.handleRetained(ctx, obj, status)
+	}
+
 	// Add finalizer
 	if obj.GetDeletionTimestamp().IsZero() {
 		err := EnsureFinalizerExists(r.Client, ctx, obj, common.JITFinalizer)
@@ -80,10 +91,23 @@ func (r *GrantProcessor) ReconcileGrant(ctx context.Context, obj common.AccessGr
 		return ctrl.Result{}, nil
 	}
 
-	// If the grant has expired, call handleExpired which cleans up the resources
-	if !status.AccessExpiresAt.IsZero() && time.Now().After(status.AccessExpiresAt.Time) {
-		err := r.handleExpired(ctx, obj, true)
-		return ctrl.Result{}, err
+	// Grants are revoked early when their request receives a Revoked response
+	if status.Revocation == nil {
+		revocation, err := r.findRevocation(ctx, obj, status)
+		if err != nil {
+			log.Error(err, "an error occurred checking whether the grant was revoked", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+		status.Revocation = revocation
+	}
+
+	// If the grant has expired or was revoked, call handleExpired which cleans up the resources
+	if status.Revocation != nil || !status.AccessExpiresAt.IsZero() && time.Now().After(status.AccessExpiresAt.Time) {
+		err := r.handleExpired(ctx, obj, status, true)
+		if err != nil || status.ExpiredAt.IsZero() {
+			return ctrl.Result{}, err
+		}
+		return r.handleRetained(ctx, obj, status)
 	}
 
 	return r.handleApproved(ctx, obj, status)
@@ -98,6 +122,41 @@ func (r *GrantProcessor) handleApproved(
 
 	scope := obj.GetScope()
 
+	// default duration fallback if not set
+	durationStr := status.Duration
+	if durationStr == "" {
+		// nolint:goconst
+		durationStr = "10m"
+	}
+
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		log.Error(err, "failed to parse duration string", "namespace", obj.GetNamespace(), "name", obj.GetName(), "duration", durationStr)
+		return ctrl.Result{}, fmt.Errorf("failed to parse duration string: %w", err)
+	}
+
+	// Scheduled grants aren't activated until their start time
+	if status.AccessExpiresAt.IsZero() && !status.StartAt.IsZero() {
+		if time.Now().Before(status.StartAt.Time) {
+			log.Info("the grant is scheduled, waiting for its start time", "name", obj.GetName(), "subject", status.Subject, "startAt", status.StartAt)
+			return ctrl.Result{RequeueAfter: time.Until(status.StartAt.Time) + time.Second}, nil
+		}
+		if scheduledEnd := status.StartAt.Add(duration); time.Now().After(scheduledEnd) {
+			log.Info("the scheduled access for the grant has already ended, expiring the grant", "name", obj.GetName(), "subject", status.Subject)
+			status.AccessExpiresAt = metav1.NewTime(scheduledEnd)
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+	}
+
+	// Don't activate a grant once the policy's access window has closed
+	if status.AccessExpiresAt.IsZero() {
+		if _, inWindow := policy.WindowEnd(status.Schedule, time.Now()); !inWindow {
+			log.Info("the access window for the grant has closed, expiring the grant", "name", obj.GetName(), "subject", status.Subject)
+			status.AccessExpiresAt = metav1.Now()
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+	}
+
 	// Handle pre-defined role or adhoc permissions
 	isClusterScoped := scope == accessv1alpha1.RequestScopeCluster
 
@@ -142,22 +201,22 @@ func (r *GrantProcessor) handleApproved(
 		}
 	}
 
-	// default duration fallback if not set
-	durationStr := status.Duration
-	if durationStr == "" {
-		// nolint:goconst
-		durationStr = "10m"
-	}
+	// Set expire time if not set, counting from the start time of scheduled grants
+	if status.AccessExpiresAt.IsZero() {
+		now := time.Now()
+		start := now
+		if !status.StartAt.IsZero() {
+			start = status.StartAt.Time
+		}
+		expiresAt := start.Add(duration)
 
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		log.Error(err, "failed to parse duration string", "namespace", obj.GetNamespace(), "name", obj.GetName(), "duration", durationStr)
-		return ctrl.Result{}, fmt.Errorf("failed to parse duration string: %w", err)
-	}
+		// Don't let the grant run past the end of the policy's access window
+		if windowEnd, _ := policy.WindowEnd(status.Schedule, now); !windowEnd.IsZero() && windowEnd.Before(expiresAt) {
+			log.Info("capping grant expiry to the end of the access window", "name", obj.GetName(), "windowEnd", windowEnd)
+			expiresAt = windowEnd
+		}
 
-	// Set expire time if not set
-	if status.AccessExpiresAt.IsZero() {
-		status.AccessExpiresAt = metav1.NewTime(time.Now().Add(duration))
+		status.AccessExpiresAt = metav1.NewTime(expiresAt)
 
 		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Granted", "AccessGranted",
 			"Just-in-time access granted to %s for request %s",
@@ -172,20 +231,34 @@ func (r *GrantProcessor) handleApproved(
 func (r *GrantProcessor) handleExpired(
 	ctx context.Context,
 	obj common.AccessGrantObject,
+	status *accessv1alpha1.AccessGrantStatus,
 	deleteGrant bool,
 ) error {
 	log := logf.FromContext(ctx)
-	status := obj.GetStatus()
+
+	// Grants counted towards a quota outlive their request, so don't let the
+	// request's deletion cascade to the grant
+	retain := deleteGrant && status.RetainAfterExpiry != ""
+
+	var requestDeleteOpts []client.DeleteOption
+	if retain {
+		requestDeleteOpts = append(requestDeleteOpts, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+	}
 
 	// Clean up any resources created for this grant
 	// Also cleans up the parent AccessRequest/ClusterAccessRequest object
-	if err := r.cleanupResources(ctx, obj); err != nil {
+	if err := r.cleanupResources(ctx, obj, requestDeleteOpts...); err != nil {
 		log.Error(err, "an error occurred running cleanup for the expired grant", "name", obj.GetName())
 		return err
 	}
 
+	if retain {
+		log.Info("resources cleaned up for expired request, retaining the grant", "name", obj.GetName(), "retainFor", status.RetainAfterExpiry)
+		status.ExpiredAt = metav1.Now()
+	}
+
 	// Delete the grant object itself
-	if deleteGrant && obj.GetDeletionTimestamp().IsZero() {
+	if deleteGrant && !retain && obj.GetDeletionTimestamp().IsZero() {
 		log.Info("resources cleaned up for expired request, deleting the grant", "name", obj.GetName())
 		if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
 			log.Error(err, "failed to delete expired grant", "name", obj.GetName())
@@ -203,9 +276,23 @@ func (r *GrantProcessor) handleExpired(
 	}
 
 	// Record an event about the revocation of access
-	r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Revoked", "AccessRevoked",
-		"Just-in-time access revoked from %s for request %s",
-		status.Subject, status.Request)
+	if revocation := status.Revocation; revocation != nil {
+		action := "revoked"
+		if revocation.Released {
+			action = "released"
+		}
+		note := fmt.Sprintf("Just-in-time access for %s was %s by %s for request %s", status.Subject, action, revocation.RevokedBy, status.Request)
+		if revocation.Reason != "" {
+			note = fmt.Sprintf("%s: %s", note, revocation.Reason)
+		}
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Revoked", "AccessRevoked", "%s", note)
+
+		metrics.GrantsRevoked.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), status.Subject, action).Inc()
+	} else {
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Revoked", "AccessRevoked",
+			"Just-in-time access revoked from %s for request %s",
+			status.Subject, status.Request)
+	}
 
 	metrics.GrantDuration.WithLabelValues(
 		string(obj.GetScope()),
@@ -217,7 +304,85 @@ func (r *GrantProcessor) handleExpired(
 	return nil
 }
 
-func (r *GrantProcessor) cleanupResources(ctx context.Context, obj common.AccessGrantObject) error {
+// findRevocation returns the revocation recorded by the earliest Revoked
+// response to the grant's request, or nil when the grant hasn't been revoked
+func (r *GrantProcessor) findRevocation(
+	ctx context.Context,
+	obj common.AccessGrantObject,
+	status *accessv1alpha1.AccessGrantStatus,
+) (*accessv1alpha1.GrantRevocation, error) {
+	var responses []common.AccessResponseObject
+	if obj.GetScope() == accessv1alpha1.RequestScopeCluster {
+		list := &accessv1alpha1.ClusterAccessResponseList{}
+		if err := r.List(ctx, list, client.MatchingFields{"spec.requestRef": status.Request}); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			responses = append(responses, &list.Items[i])
+		}
+	} else {
+		list := &accessv1alpha1.AccessResponseList{}
+		if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{"spec.requestRef": status.Request}); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			responses = append(responses, &list.Items[i])
+		}
+	}
+
+	var earliest common.AccessResponseObject
+	for _, resp := range responses {
+		if resp.GetResponse() != accessv1alpha1.ResponseStateRevoked {
+			continue
+		}
+		if earliest == nil || resp.GetCreationTimestamp().Time.Before(earliest.GetCreationTimestamp().Time) {
+			earliest = resp
+		}
+	}
+	if earliest == nil {
+		return nil, nil
+	}
+
+	revokedBy := earliest.GetApprover()
+	return &accessv1alpha1.GrantRevocation{
+		RevokedBy: revokedBy,
+		Reason:    earliest.GetReason(),
+		Released:  revokedBy == status.Subject || status.Delegation != nil && revokedBy == status.Delegation.Delegator,
+		RevokedAt: earliest.GetCreationTimestamp(),
+	}, nil
+}
+
+func (r *GrantProcessor) handleRetained(
+	ctx context.Context,
+	obj common.AccessGrantObject,
+	status *accessv1alpha1.AccessGrantStatus,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	retention, err := time.ParseDuration(status.RetainAfterExpiry)
+	if err != nil {
+		log.Error(err, "failed to parse retention period, deleting the grant", "name", obj.GetName(), "retainAfterExpiry", status.RetainAfterExpiry)
+		retention = 0
+	}
+
+	if remaining := time.Until(status.ExpiredAt.Add(retention)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining + time.Second}, nil
+	}
+
+	log.Info("retention period for expired grant has passed, deleting the grant", "name", obj.GetName())
+	if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		log.Error(err, "failed to delete retained grant", "name", obj.GetName())
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *GrantProcessor) cleanupResources(
+	ctx context.Context,
+	obj common.AccessGrantObject,
+	requestDeleteOpts ...client.DeleteOption,
+) error {
 	log := logf.FromContext(ctx)
 	status := obj.GetStatus()
 	scope := obj.GetScope()
@@ -227,9 +392,9 @@ func (r *GrantProcessor) cleanupResources(ctx context.Context, obj common.Access
 
 	var errs []error
 
-	deleteResource := func(key client.ObjectKey, obj client.Object, description string) {
+	deleteResource := func(key client.ObjectKey, obj client.Object, description string, opts ...client.DeleteOption) {
 		if err := r.Get(ctx, key, obj); err == nil {
-			if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+			if err := r.Delete(ctx, obj, opts...); err != nil && !k8serrors.IsNotFound(err) {
 				errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", description, key.Name, err))
 			} else {
 				log.Info("Deleted "+description, "name", key.Name)
@@ -289,7 +454,7 @@ func (r *GrantProcessor) cleanupResources(ctx context.Context, obj common.Access
 
 	// Delete the Request object
 	reqKey := client.ObjectKey{Name: status.Request}
-	var reqObj client.Object
+	var reqObj common.AccessRequestObject
 	var reqType string
 	if scope == accessv1alpha1.RequestScopeCluster {
 		reqObj = &accessv1alpha1.ClusterAccessRequest{}
@@ -299,7 +464,23 @@ func (r *GrantProcessor) cleanupResources(ctx context.Context, obj common.Access
 		reqKey.Namespace = obj.GetNamespace()
 		reqType = "AccessRequest"
 	}
-	deleteResource(reqKey, reqObj, reqType)
+
+	// Break-glass requests are kept until they've been reviewed, the request
+	// processor deletes them once the review is recorded
+	pendingReview := false
+	if status.Incident != "" {
+		if err := r.Get(ctx, reqKey, reqObj); err == nil {
+			pendingReview = reqObj.GetStatus().State == accessv1alpha1.RequestStatePendingReview
+		}
+	}
+	switch {
+	case pendingReview:
+		log.Info("keeping the break-glass request until it has been reviewed", "name", status.Request)
+	case status.AccessSchedule != "":
+		// The schedule's request approves every occurrence, it's deleted with the schedule
+	default:
+		deleteResource(reqKey, reqObj, reqType, requestDeleteOpts...)
+	}
 
 	if len(errs) > 0 {
 		return errors.Join(errs...)
@@ -353,7 +534,7 @@ func (r *GrantProcessor) createRoleBinding(
 	labels := common.CommonLabels()
 
 	isClusterScoped := scope == accessv1alpha1.RequestScopeCluster
-	subject := rbacv1.Subject{Kind: "User", Name: status.Subject, APIGroup: "rbac.authorization.k8s.io"}
+	subject := policy.GrantSubject(status.GrantTarget, status.Subject)
 
 	var roleBinding client.Object
 
@@ -0,0 +1,209 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+)
+
+// IncidentRevokerPrefix prefixes the revoker recorded on grants revoked when
+// their incident closed
+const IncidentRevokerPrefix = "incident-mode:"
+
+type IncidentProcessor struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+	// PolicyManagers hold the policies the incidents' overrides apply to
+	PolicyManagers []*policy.PolicyManager
+}
+
+func (r *IncidentProcessor) ReconcileIncident(ctx context.Context, obj *v1alpha1.IncidentMode) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	originalStatus := *obj.Status.DeepCopy()
+	status := obj.Status.DeepCopy()
+
+	base := obj.DeepCopy()
+
+	defer func() {
+		if obj.GetDeletionTimestamp().IsZero() {
+			if !equality.Semantic.DeepEqual(originalStatus, *status) {
+				obj.Status = *status
+
+				if err := r.Status().Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+					log.Error(err, "failed to persist status with patch")
+				}
+			}
+		}
+	}()
+
+	now := time.Now()
+
+	// Closed incidents, including deleted ones, stop applying to policies straight away
+	if err := r.refreshPolicies(ctx, now); err != nil {
+		log.Error(err, "an error occurred updating the policies with the active incidents")
+		return ctrl.Result{}, err
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if status.ClosedAt.IsZero() && obj.Spec.RevokeOnClose {
+			if _, err := r.revokeGrants(ctx, obj, now); err != nil {
+				log.Error(err, "an error occurred revoking the grants of the deleted incident", "name", obj.GetName())
+				return ctrl.Result{}, err
+			}
+		}
+		if err := RemoveFinalizer(r.Client, ctx, obj, common.JITFinalizer); err != nil && !k8serrors.IsNotFound(err) {
+			log.Error(err, "an error occurred removing the incident finalizer", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Closed incidents have nothing left to do
+	if !status.ClosedAt.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := EnsureFinalizerExists(r.Client, ctx, obj, common.JITFinalizer); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "an error occurred adding the finalizer to the incident", "name", obj.GetName())
+		return ctrl.Result{}, err
+	}
+
+	expiresAt := policy.IncidentExpiry(obj)
+	if !expiresAt.IsZero() {
+		status.ExpiresAt = metav1.NewTime(expiresAt)
+	}
+
+	if policy.IncidentActive(obj, now) {
+		if status.State == "" {
+			status.State = v1alpha1.IncidentModeStateActive
+			log.Info("incident opened, applying policy overrides", "name", obj.GetName())
+			r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "Opened", "IncidentOpened",
+				"Incident %s opened, policy overrides apply until it is closed", obj.GetName())
+		}
+
+		if expiresAt.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: time.Until(expiresAt)}, nil
+	}
+
+	revoked := 0
+	if obj.Spec.RevokeOnClose {
+		var err error
+		revoked, err = r.revokeGrants(ctx, obj, now)
+		if err != nil {
+			log.Error(err, "an error occurred revoking the grants of the closed incident", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.Info("incident closed, policy overrides no longer apply", "name", obj.GetName(), "revokedGrants", revoked)
+	status.State = v1alpha1.IncidentModeStateClosed
+	status.ClosedAt = metav1.NewTime(now)
+	status.RevokedGrants = revoked
+
+	if obj.Spec.RevokeOnClose {
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Closed", "IncidentClosed",
+			"Incident %s closed and %d grants created under it were revoked", obj.GetName(), revoked)
+	} else {
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Closed", "IncidentClosed",
+			"Incident %s closed", obj.GetName())
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// refreshPolicies updates the policy managers with the incidents that are
+// active at the given time
+func (r *IncidentProcessor) refreshPolicies(ctx context.Context, now time.Time) error {
+	var list v1alpha1.IncidentModeList
+	if err := r.List(ctx, &list); err != nil {
+		return err
+	}
+
+	for _, manager := range r.PolicyManagers {
+		manager.UpdateIncidents(list.Items, now)
+	}
+	return nil
+}
+
+// revokeGrants revokes the active grants created under the incident and
+// returns the number of grants the incident revoked
+func (r *IncidentProcessor) revokeGrants(ctx context.Context, obj *v1alpha1.IncidentMode, now time.Time) (int, error) {
+	log := logf.FromContext(ctx)
+
+	var grants []common.AccessGrantObject
+
+	clusterGrants := &v1alpha1.ClusterAccessGrantList{}
+	if err := r.List(ctx, clusterGrants, client.MatchingFields{"status.incidentMode": obj.GetName()}); err != nil {
+		return 0, err
+	}
+	for i := range clusterGrants.Items {
+		grants = append(grants, &clusterGrants.Items[i])
+	}
+
+	namespacedGrants := &v1alpha1.AccessGrantList{}
+	if err := r.List(ctx, namespacedGrants, client.MatchingFields{"status.incidentMode": obj.GetName()}); err != nil {
+		return 0, err
+	}
+	for i := range namespacedGrants.Items {
+		grants = append(grants, &namespacedGrants.Items[i])
+	}
+
+	revokedBy := IncidentRevokerPrefix + obj.GetName()
+	revoked := 0
+
+	for _, grant := range grants {
+		status := grant.GetStatus()
+		if status.Revocation != nil {
+			if status.Revocation.RevokedBy == revokedBy {
+				revoked++
+			}
+			continue
+		}
+		// Grants retained for quota tracking have already expired
+		if !status.ExpiredAt.IsZero() {
+			continue
+		}
+
+		base := grant.DeepCopyObject().(client.Object)
+		updated := status.DeepCopy()
+		updated.Revocation = &v1alpha1.GrantRevocation{
+			RevokedBy: revokedBy,
+			Reason:    fmt.Sprintf("incident %s was closed", obj.GetName()),
+			RevokedAt: metav1.NewTime(now),
+		}
+		grant.SetStatus(updated)
+
+		if err := r.Status().Patch(ctx, grant, client.MergeFrom(base)); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return revoked, fmt.Errorf("failed to revoke grant %s: %w", grant.GetName(), err)
+		}
+
+		log.Info("revoked grant created under the closed incident", "name", obj.GetName(), "grant", grant.GetName(), "namespace", grant.GetNamespace())
+		revoked++
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,186 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type PolicyProcessor struct {
+	client.Client
+	PolicyManager *policy.PolicyManager
+}
+
+// policyUsage counts the requests and grants attributed to each policy
+type policyUsage struct {
+	pendingRequests map[types.NamespacedName]int
+	activeGrants    map[types.NamespacedName]int
+}
+
+// ReconcilePolicyStatus validates the policies and updates their conditions
+// and usage counts. The PolicyManager must already hold the policies.
+func (r *PolicyProcessor) ReconcilePolicyStatus(ctx context.Context, policies []common.AccessPolicyStatusObject) error {
+	log := logf.FromContext(ctx)
+
+	usage, err := r.countUsage(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := r.PolicyManager.GetSnapshot()
+
+	for _, obj := range policies {
+		effective := common.AccessPolicyObject(obj)
+		for _, p := range snapshot {
+			if p.GetName() == obj.GetName() && p.GetNamespace() == obj.GetNamespace() {
+				effective = p
+				break
+			}
+		}
+
+		conditions, err := policy.ValidatePolicy(ctx, r.Client, effective, r.PolicyManager.TemplateExists(obj.GetPolicy().Template))
+		if err != nil {
+			log.Error(err, "an error occurred validating the policy", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return err
+		}
+
+		if shadow := policy.ShadowedBy(effective, snapshot); shadow != nil {
+			conditions = append(conditions, metav1.Condition{
+				Type:    policy.ConditionShadowed,
+				Status:  metav1.ConditionTrue,
+				Reason:  "ShadowedByPolicy",
+				Message: fmt.Sprintf("policy %s is evaluated first and matches every request this policy matches", shadow.GetName()),
+			})
+		} else {
+			conditions = append(conditions, metav1.Condition{
+				Type:    policy.ConditionShadowed,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NotShadowed",
+				Message: "the policy can match requests",
+			})
+		}
+
+		conditions = append(conditions, readyCondition(conditions))
+
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		status := obj.GetStatus().DeepCopy()
+		for _, condition := range conditions {
+			condition.ObservedGeneration = obj.GetGeneration()
+			meta.SetStatusCondition(&status.Conditions, condition)
+		}
+		status.PendingRequests = usage.pendingRequests[key]
+		status.ActiveGrants = usage.activeGrants[key]
+
+		if equality.Semantic.DeepEqual(obj.GetStatus(), status) {
+			continue
+		}
+
+		base := obj.DeepCopyObject().(client.Object)
+		obj.SetStatus(status)
+		if err := r.Status().Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+			log.Error(err, "failed to persist policy status with patch", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readyCondition summarizes the policy's conditions
+func readyCondition(conditions []metav1.Condition) metav1.Condition {
+	for _, condition := range conditions {
+		failed := condition.Status == metav1.ConditionFalse
+		if condition.Type == policy.ConditionShadowed {
+			failed = condition.Status == metav1.ConditionTrue
+		}
+		if failed {
+			return metav1.Condition{
+				Type:    policy.ConditionReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+		}
+	}
+
+	return metav1.Condition{
+		Type:    policy.ConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "PolicyValid",
+		Message: "the policy is valid",
+	}
+}
+
+// countUsage counts pending requests and active grants by the policy they were
+// resolved to. Cluster policies are keyed without a namespace.
+func (r *PolicyProcessor) countUsage(ctx context.Context) (*policyUsage, error) {
+	usage := &policyUsage{
+		pendingRequests: map[types.NamespacedName]int{},
+		activeGrants:    map[types.NamespacedName]int{},
+	}
+
+	policyKey := func(name string, scope v1alpha1.PolicyScope, namespace string) types.NamespacedName {
+		if scope == "Cluster" || namespace == "" {
+			return types.NamespacedName{Name: name}
+		}
+		return types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	requests := &v1alpha1.AccessRequestList{}
+	if err := r.List(ctx, requests); err != nil {
+		return nil, err
+	}
+	for _, req := range requests.Items {
+		if req.Status.State == v1alpha1.RequestStatePending && req.Status.ResolvedPolicy != "" {
+			usage.pendingRequests[policyKey(req.Status.ResolvedPolicy, req.Status.ResolvedPolicyScope, req.Namespace)]++
+		}
+	}
+
+	clusterRequests := &v1alpha1.ClusterAccessRequestList{}
+	if err := r.List(ctx, clusterRequests); err != nil {
+		return nil, err
+	}
+	for _, req := range clusterRequests.Items {
+		if req.Status.State == v1alpha1.RequestStatePending && req.Status.ResolvedPolicy != "" {
+			usage.pendingRequests[policyKey(req.Status.ResolvedPolicy, "Cluster", "")]++
+		}
+	}
+
+	now := time.Now()
+	active := func(status *v1alpha1.AccessGrantStatus) bool {
+		return status.Policy != "" && status.ExpiredAt.IsZero() &&
+			(status.AccessExpiresAt.IsZero() || status.AccessExpiresAt.After(now))
+	}
+
+	grants := &v1alpha1.AccessGrantList{}
+	if err := r.List(ctx, grants); err != nil {
+		return nil, err
+	}
+	for _, grant := range grants.Items {
+		if active(&grant.Status) {
+			usage.activeGrants[policyKey(grant.Status.Policy, grant.Status.PolicyScope, grant.Namespace)]++
+		}
+	}
+
+	clusterGrants := &v1alpha1.ClusterAccessGrantList{}
+	if err := r.List(ctx, clusterGrants); err != nil {
+		return nil, err
+	}
+	for _, grant := range clusterGrants.Items {
+		if active(&grant.Status) {
+			usage.activeGrants[policyKey(grant.Status.Policy, "Cluster", "")]++
+		}
+	}
+
+	return usage, nil
+}
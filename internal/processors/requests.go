@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	common "github.com/itsthatdude/jit-access-controller/internal/common"
 	"github.com/itsthatdude/jit-access-controller/internal/metrics"
+	"github.com/itsthatdude/jit-access-controller/internal/oncall"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
 	"github.com/itsthatdude/jit-access-controller/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -19,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	set "k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -27,9 +33,12 @@ import (
 
 type RequestProcessor struct {
 	client.Client
-	Scheme         *runtime.Scheme
-	PolicyManager  *policy.PolicyManager
-	PolicyResolver *policy.PolicyResolver
+	Scheme          *runtime.Scheme
+	Recorder        events.EventRecorder
+	PolicyManager   *policy.PolicyManager
+	PolicyResolver  *policy.PolicyResolver
+	TicketValidator *ticket.Validator
+	OnCall          *oncall.Resolver
 }
 
 func (r *RequestProcessor) ReconcileRequest(ctx context.Context, obj common.AccessRequestObject) (ctrl.Result, error) {
@@ -67,11 +76,6 @@ func (r *RequestProcessor) ReconcileRequest(ctx context.Context, obj common.Acce
 		metrics.RequestsCreated.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
 	}
 
-	// Set request expire time if not set
-	if status.RequestExpiresAt.IsZero() {
-		status.RequestExpiresAt = metav1.NewTime(time.Now().Add(time.Duration(60) * time.Minute))
-	}
-
 	// Handle deletion
 	if !obj.GetDeletionTimestamp().IsZero() {
 		if controllerutil.ContainsFinalizer(obj, common.JITFinalizer) {
@@ -112,7 +116,8 @@ func (r *RequestProcessor) ReconcileRequest(ctx context.Context, obj common.Acce
 		}
 	}
 
-	if status.State != v1alpha1.RequestStateApproved &&
+	// Granted requests, including break-glass requests awaiting review, don't expire
+	if status.State != v1alpha1.RequestStateApproved && status.State != v1alpha1.RequestStatePendingReview &&
 		!status.RequestExpiresAt.IsZero() && time.Now().After(status.RequestExpiresAt.Time) {
 		status.State = v1alpha1.RequestStateExpired
 	}
@@ -125,43 +130,90 @@ func (r *RequestProcessor) ReconcileRequest(ctx context.Context, obj common.Acce
 	// Match against policies
 	var policies = r.PolicyManager.GetSnapshot()
 
-	matched_policy := r.PolicyResolver.Resolve(obj, policies)
+	matched_policy, trace := r.PolicyResolver.ResolveWithTrace(ctx, obj, policies)
 	if matched_policy == nil {
-		return ctrl.Result{}, fmt.Errorf("the request does not match an access policy")
+		// Requests that never match a policy still expire
+		if status.RequestExpiresAt.IsZero() {
+			status.RequestExpiresAt = metav1.NewTime(time.Now().Add(policy.DefaultPendingTimeout))
+		}
+		return ctrl.Result{}, fmt.Errorf("the request does not match an access policy: %s", strings.Join(trace.Reasons(), "; "))
 	}
 
 	policyName := matched_policy.GetName()
 	policySpec := matched_policy.GetPolicy()
 
+	// Extensions are approved under the policy's extension rules
+	if obj.GetSpec().Extends != "" {
+		policySpec = policy.ExtensionApprovalPolicy(policySpec)
+	}
+
+	// Set request expire time if not set
+	if status.RequestExpiresAt.IsZero() {
+		status.RequestExpiresAt = metav1.NewTime(time.Now().Add(policy.PendingTimeout(&policySpec)))
+	}
+
 	if status.ResolvedPolicy == "" {
 		status.ResolvedPolicy = policyName
+		status.ResolvedPolicyScope = matched_policy.GetScope()
 	}
 
-	if policySpec.RequiredApprovals != status.ApprovalsRequired {
-		status.ApprovalsRequired = policySpec.RequiredApprovals
+	// Requests approved while an incident's overrides apply are granted under the incident
+	if status.State == v1alpha1.RequestStatePending {
+		status.IncidentMode = policy.IncidentMode(matched_policy)
+	}
+
+	if spec := obj.GetSpec(); spec.Delegator != "" && status.Delegation == nil {
+		status.Delegation = &v1alpha1.DelegationStatus{Delegator: spec.Delegator, Beneficiary: spec.Subject}
+	}
+
+	if requiredApprovals := policy.RequiredApprovals(&policySpec); requiredApprovals != status.ApprovalsRequired {
+		status.ApprovalsRequired = requiredApprovals
+	}
+
+	// Record the ticket the request was validated against
+	if policySpec.TicketValidation != nil && status.Ticket == nil && status.State == v1alpha1.RequestStatePending {
+		ticketStatus, err := r.TicketValidator.Validate(ctx, obj, policySpec.TicketValidation)
+		if errors.Is(err, ticket.ErrRejected) {
+			log.Info("denying request as its ticket was rejected", "name", obj.GetName(), "reason", err.Error())
+			status.State = v1alpha1.RequestStateDenied
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:    "GrantCreated",
+				Status:  metav1.ConditionFalse,
+				Reason:  "TicketRejected",
+				Message: err.Error(),
+			})
+			r.updateRequestStatusMetric(obj, status.State)
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			log.Error(err, "an error occurred validating the ticket for the request", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+		status.Ticket = ticketStatus
 	}
 
 	if status.State == v1alpha1.RequestStatePending {
 		return r.handlePendingRequest(ctx, obj, &policySpec, status)
 	}
 
+	if status.State == v1alpha1.RequestStatePendingReview {
+		return r.handlePendingReview(ctx, obj, status)
+	}
+
 	return ctrl.Result{}, nil
 }
 
 func (r *RequestProcessor) approveRequest(
 	ctx context.Context,
 	obj common.AccessRequestObject,
+	matchedPolicy *v1alpha1.SubjectPolicy,
 	status *v1alpha1.AccessRequestStatus,
 	approvers []string,
 ) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 	spec := obj.GetSpec()
 
-	durationStr := spec.Duration
-	if durationStr == "" {
-		// nolint:goconst
-		durationStr = "10m"
-	}
+	durationStr := policy.RequestDuration(matchedPolicy, spec.Duration)
 
 	duration, err := time.ParseDuration(durationStr)
 	if err != nil {
@@ -169,32 +221,179 @@ func (r *RequestProcessor) approveRequest(
 		return ctrl.Result{}, err
 	}
 
-	if err := r.createGrant(ctx, obj, status, approvers); err != nil && !k8serrors.IsAlreadyExists(err) {
+	if err := r.createGrant(ctx, obj, matchedPolicy, status, approvers); err != nil && !k8serrors.IsAlreadyExists(err) {
 		log.Error(err, "an error occurred creating the access grant for the request", "name", obj.GetName(), "subject", spec.Subject, "role", spec.Role)
 		return ctrl.Result{}, err
 	}
 
-	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
-		Type:    "GrantCreated",
-		Status:  metav1.ConditionTrue,
-		Reason:  "RequestApproved",
-		Message: "The request was approved and access has been granted",
-	})
+	if spec.BreakGlass != nil {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "GrantCreated",
+			Status:  metav1.ConditionTrue,
+			Reason:  "BreakGlass",
+			Message: fmt.Sprintf("Access was granted without approvals for incident %s and must be reviewed", spec.BreakGlass.Incident),
+		})
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "BreakGlass", "BreakGlassAccess",
+			"Break-glass access granted to %s without approvals for incident %s", spec.Subject, spec.BreakGlass.Incident)
 
-	metrics.RequestsApproved.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+		metrics.BreakGlassGrants.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+	} else if spec.StartAt != nil && spec.StartAt.After(time.Now()) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "GrantCreated",
+			Status:  metav1.ConditionTrue,
+			Reason:  "RequestScheduled",
+			Message: fmt.Sprintf("The request was approved and access will be granted at %s", spec.StartAt.Format(time.RFC3339)),
+		})
+
+		metrics.RequestsApproved.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "GrantCreated",
+			Status:  metav1.ConditionTrue,
+			Reason:  "RequestApproved",
+			Message: "The request was approved and access has been granted",
+		})
+
+		metrics.RequestsApproved.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+	}
 
 	if spec.Role.Name != "" {
 		metrics.RolesGranted.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject(), spec.Role.Kind, spec.Role.Name).Inc()
 	}
 
-	r.updateRequestStatusMetric(obj, v1alpha1.RequestStateApproved)
+	r.updateRequestStatusMetric(obj, status.State)
 
 	if len(spec.Permissions) > 0 {
 		r.recordPermissionMetrics(obj, spec.Permissions)
 	}
 
 	// Requeue just after access expiry to handle cleanup
-	return ctrl.Result{RequeueAfter: duration + time.Second}, nil
+	return ctrl.Result{RequeueAfter: time.Until(policy.AccessStart(spec, time.Now())) + duration + time.Second}, nil
+}
+
+// approveSchedule records the approval of the request created for an access
+// schedule. The schedule creates a grant for each of its occurrences.
+func (r *RequestProcessor) approveSchedule(
+	ctx context.Context,
+	obj common.AccessRequestObject,
+	status *v1alpha1.AccessRequestStatus,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	spec := obj.GetSpec()
+
+	log.Info("approved access schedule", "name", obj.GetName(), "schedule", spec.AccessSchedule)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    "ScheduleApproved",
+		Status:  metav1.ConditionTrue,
+		Reason:  "RequestApproved",
+		Message: fmt.Sprintf("The request was approved, access schedule %s grants access at each occurrence", spec.AccessSchedule),
+	})
+
+	metrics.RequestsApproved.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+	r.updateRequestStatusMetric(obj, status.State)
+
+	return ctrl.Result{}, nil
+}
+
+// extendGrant pushes out the expiry of the grant the request extends. The
+// grant's roles and bindings are left in place.
+func (r *RequestProcessor) extendGrant(
+	ctx context.Context,
+	obj common.AccessRequestObject,
+	matchedPolicy *v1alpha1.SubjectPolicy,
+	status *v1alpha1.AccessRequestStatus,
+	approvers []string,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	spec := obj.GetSpec()
+	now := time.Now()
+	durationStr := policy.RequestDuration(matchedPolicy, spec.Duration)
+
+	var reason string
+	grant, err := policy.ExtendedGrant(ctx, r.Client, obj)
+	switch {
+	case k8serrors.IsNotFound(err):
+		reason = fmt.Sprintf("grant %s does not exist", spec.Extends)
+	case err != nil:
+		log.Error(err, "an error occurred fetching the grant to extend", "name", obj.GetName(), "grant", spec.Extends)
+		status.State = v1alpha1.RequestStatePending
+		return ctrl.Result{}, err
+	case grant.GetStatus().Policy != status.ResolvedPolicy || grant.GetStatus().PolicyScope != status.ResolvedPolicyScope:
+		reason = fmt.Sprintf("the request matched policy %s, but the grant was authorized by policy %s", status.ResolvedPolicy, grant.GetStatus().Policy)
+	case policy.IsExtendedBy(grant.GetStatus(), obj.GetName()):
+		// The grant was extended by an earlier reconcile
+	default:
+		reason = policy.CheckExtension(matchedPolicy.Extensions, grant.GetStatus(), spec.Subject, durationStr, now)
+	}
+
+	if reason != "" {
+		log.Info("denying extension request", "name", obj.GetName(), "grant", spec.Extends, "reason", reason)
+		status.State = v1alpha1.RequestStateDenied
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "GrantExtended",
+			Status:  metav1.ConditionFalse,
+			Reason:  "ExtensionRejected",
+			Message: reason,
+		})
+		r.updateRequestStatusMetric(obj, status.State)
+		return ctrl.Result{}, nil
+	}
+
+	if grantStatus := grant.GetStatus(); !policy.IsExtendedBy(grantStatus, obj.GetName()) {
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			log.Error(err, "failed to parse duration string", "duration", durationStr)
+			return ctrl.Result{}, err
+		}
+
+		original := grant.DeepCopyObject().(client.Object)
+
+		expiresAt := grantStatus.AccessExpiresAt.Add(duration)
+		if windowEnd, _ := policy.WindowEnd(grantStatus.Schedule, now); !windowEnd.IsZero() && windowEnd.Before(expiresAt) {
+			log.Info("capping grant extension to the end of the access window", "name", obj.GetName(), "windowEnd", windowEnd)
+			expiresAt = windowEnd
+		}
+		grantStatus.AccessExpiresAt = metav1.NewTime(expiresAt)
+		grantStatus.Extensions = append(grantStatus.Extensions, v1alpha1.GrantExtension{
+			Request:    obj.GetName(),
+			Duration:   durationStr,
+			ApprovedBy: approvers,
+			ExtendedAt: metav1.NewTime(now),
+		})
+		// Grants extended under an incident's overrides are revoked with the incident
+		if status.IncidentMode != "" {
+			grantStatus.IncidentMode = status.IncidentMode
+		}
+
+		if err := r.Status().Patch(ctx, grant, client.MergeFrom(original)); err != nil {
+			log.Error(err, "an error occurred extending the grant", "name", obj.GetName(), "grant", spec.Extends)
+			status.State = v1alpha1.RequestStatePending
+			return ctrl.Result{}, err
+		}
+		log.Info("Extended grant", "name", obj.GetName(), "grant", spec.Extends, "expiresAt", expiresAt)
+	}
+
+	// Clean up the extension request along with the grant
+	owned := obj.DeepCopyObject().(client.Object)
+	if err := controllerutil.SetOwnerReference(grant, owned, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner reference on request %s: %w", obj.GetName(), err)
+	}
+	if err := r.Patch(ctx, owned, client.MergeFrom(obj)); err != nil {
+		log.Error(err, "an error occurred setting the grant as the owner of the extension request", "name", obj.GetName())
+		return ctrl.Result{}, err
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    "GrantExtended",
+		Status:  metav1.ConditionTrue,
+		Reason:  "ExtensionApproved",
+		Message: fmt.Sprintf("Grant %s was extended by %s", spec.Extends, durationStr),
+	})
+
+	metrics.RequestsApproved.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject()).Inc()
+	r.updateRequestStatusMetric(obj, v1alpha1.RequestStateApproved)
+
+	return ctrl.Result{}, nil
 }
 
 func (r *RequestProcessor) handlePendingRequest(
@@ -209,7 +408,7 @@ func (r *RequestProcessor) handlePendingRequest(
 	approved := set.New[string]()
 	denied := set.New[string]()
 
-	approvals := set.New[v1alpha1.AccessRequestApproval]()
+	approvals := []policy.Approval{}
 
 	// Fetch responses
 	if obj.GetScope() == v1alpha1.RequestScopeCluster {
@@ -223,12 +422,16 @@ func (r *RequestProcessor) handlePendingRequest(
 			if err := controllerutil.SetControllerReference(obj, &resp, r.Scheme); err != nil {
 				log.Error(err, "an error occurred setting the controller reference for the response", "name", resp.GetName())
 			}
-			if matchedPolicy.AllowSelfApproval || resp.Spec.Approver != spec.Subject {
+			if matchedPolicy.AllowSelfApproval || !policy.IsSelfApproval(*spec, resp.Spec.Approver) {
 				switch resp.Spec.Response {
 				case v1alpha1.ResponseStateApproved:
+					if approved.Has(resp.Spec.Approver) {
+						continue
+					}
 					approved.Insert(resp.Spec.Approver)
-					approvals.Insert(v1alpha1.AccessRequestApproval{
+					approvals = append(approvals, policy.Approval{
 						Approver:   resp.Spec.Approver,
+						Groups:     resp.Spec.Groups,
 						ApprovedAt: resp.CreationTimestamp,
 					})
 				case v1alpha1.ResponseStateDenied:
@@ -247,12 +450,16 @@ func (r *RequestProcessor) handlePendingRequest(
 			if err := controllerutil.SetControllerReference(obj, &resp, r.Scheme); err != nil {
 				log.Error(err, "an error occurred setting the controller reference for the response", "name", resp.GetName())
 			}
-			if matchedPolicy.AllowSelfApproval || resp.Spec.Approver != spec.Subject {
+			if matchedPolicy.AllowSelfApproval || !policy.IsSelfApproval(*spec, resp.Spec.Approver) {
 				switch resp.Spec.Response {
 				case v1alpha1.ResponseStateApproved:
+					if approved.Has(resp.Spec.Approver) {
+						continue
+					}
 					approved.Insert(resp.Spec.Approver)
-					approvals.Insert(v1alpha1.AccessRequestApproval{
+					approvals = append(approvals, policy.Approval{
 						Approver:   resp.Spec.Approver,
+						Groups:     resp.Spec.Groups,
 						ApprovedAt: resp.CreationTimestamp,
 					})
 				case v1alpha1.ResponseStateDenied:
@@ -262,19 +469,86 @@ func (r *RequestProcessor) handlePendingRequest(
 		}
 	}
 
-	if approved.Len() != status.ApprovalsReceived {
-		status.ApprovalsReceived = approved.Len()
-		status.Approvals = approvals.UnsortedList()
+	autoApproval, onCall, err := r.PolicyResolver.AutoApprove(ctx, obj, matchedPolicy.AutoApprovals, r.OnCall)
+	if err != nil {
+		log.Error(err, "an error occurred evaluating auto-approval rules for the request", "name", obj.GetName())
+		return ctrl.Result{}, err
 	}
 
-	if denied.Len() > 0 {
+	stagesComplete := true
+	if len(matchedPolicy.ApprovalStages) > 0 {
+		status.Stages, status.CurrentStage, stagesComplete = policy.EvaluateStages(matchedPolicy.ApprovalStages, approvals)
+	}
+
+	// Record the group each approval was counted under for the quorum
+	quorumGroups := map[string]string{}
+	quorumMet := true
+	if len(matchedPolicy.ApprovalQuorum) > 0 {
+		var unmet []string
+		quorumGroups, unmet = policy.EvaluateQuorum(matchedPolicy.ApprovalQuorum, approvals)
+		quorumMet = len(unmet) == 0
+		if !quorumMet {
+			log.V(1).Info("request is waiting for approvals from quorum groups", "name", obj.GetName(), "groups", unmet)
+		}
+	}
+
+	status.ApprovalsReceived = approved.Len()
+	status.Approvals = recordApprovals(approvals, quorumGroups)
+
+	approvers := approved.UnsortedList()
+	if autoApproval != nil && denied.Len() == 0 && spec.BreakGlass == nil {
+		record := policy.AutoApproval(autoApproval, onCall, metav1.Now())
+		status.Approvals = append(status.Approvals, record)
+		approvers = append(approvers, record.Approver)
+	}
+
+	switch {
+	case denied.Len() > 0:
 		status.State = v1alpha1.RequestStateDenied
-	} else if approved.Len() >= matchedPolicy.RequiredApprovals {
+	case spec.BreakGlass != nil && matchedPolicy.BreakGlass != nil:
+		log.Info("granting break-glass request without approvals", "name", obj.GetName(), "incident", spec.BreakGlass.Incident)
+		status.State = v1alpha1.RequestStatePendingReview
+	case autoApproval != nil:
+		log.Info("auto-approving request", "name", obj.GetName(), "rule", autoApproval.Name)
 		status.State = v1alpha1.RequestStateApproved
+	case !quorumMet:
+		// Wait for approvals from every quorum group
+	case len(matchedPolicy.ApprovalStages) > 0:
+		if stagesComplete {
+			status.State = v1alpha1.RequestStateApproved
+		}
+	case approved.Len() >= matchedPolicy.RequiredApprovals:
+		status.State = v1alpha1.RequestStateApproved
+	}
+
+	if status.State == v1alpha1.RequestStateApproved && spec.Extends != "" {
+		return r.extendGrant(ctx, obj, matchedPolicy, status, approvers)
 	}
 
-	if status.State == v1alpha1.RequestStateApproved {
-		return r.approveRequest(ctx, obj, status, approved.UnsortedList())
+	if status.State == v1alpha1.RequestStateApproved && spec.AccessSchedule != "" {
+		return r.approveSchedule(ctx, obj, status)
+	}
+
+	if status.State == v1alpha1.RequestStateApproved || status.State == v1alpha1.RequestStatePendingReview {
+		violation, err := policy.CheckQuota(ctx, r.Client, obj, matchedPolicy.Quota, time.Now())
+		if err != nil {
+			log.Error(err, "an error occurred checking the quota for the request", "name", obj.GetName())
+			status.State = v1alpha1.RequestStatePending
+			return ctrl.Result{}, err
+		}
+
+		if violation == "" {
+			return r.approveRequest(ctx, obj, matchedPolicy, status, approvers)
+		}
+
+		log.Info("denying request as it would exceed the policy quota", "name", obj.GetName(), "reason", violation)
+		status.State = v1alpha1.RequestStateDenied
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "GrantCreated",
+			Status:  metav1.ConditionFalse,
+			Reason:  "QuotaExceeded",
+			Message: violation,
+		})
 	}
 
 	r.updateRequestStatusMetric(obj, status.State)
@@ -286,6 +560,155 @@ func (r *RequestProcessor) handlePendingRequest(
 	return ctrl.Result{}, nil
 }
 
+// handlePendingReview records the review of a break-glass request. The
+// request is kept after its grant ends until it has been reviewed, so it's
+// deleted here once reviewed if the grant is gone.
+func (r *RequestProcessor) handlePendingReview(
+	ctx context.Context,
+	obj common.AccessRequestObject,
+	status *v1alpha1.AccessRequestStatus,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	spec := obj.GetSpec()
+
+	var reviews []common.BreakGlassReviewObject
+	if obj.GetScope() == v1alpha1.RequestScopeCluster {
+		list := &v1alpha1.ClusterBreakGlassReviewList{}
+		if err := r.List(ctx, list, client.MatchingFields{"spec.requestRef": obj.GetName()}); err != nil {
+			log.Error(err, "an error occurred fetching reviews for the request", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+		for i := range list.Items {
+			reviews = append(reviews, &list.Items[i])
+		}
+	} else {
+		list := &v1alpha1.BreakGlassReviewList{}
+		if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{"spec.requestRef": obj.GetName()}); err != nil {
+			log.Error(err, "an error occurred fetching reviews for the request", "name", obj.GetName())
+			return ctrl.Result{}, err
+		}
+		for i := range list.Items {
+			reviews = append(reviews, &list.Items[i])
+		}
+	}
+
+	review := policy.EarliestReview(*spec, reviews)
+	if review == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// Clean up the reviews along with the request
+	for _, item := range reviews {
+		owned := item.DeepCopyObject().(client.Object)
+		if err := controllerutil.SetControllerReference(obj, owned, r.Scheme); err != nil {
+			log.Error(err, "an error occurred setting the controller reference for the review", "name", item.GetName())
+			continue
+		}
+		if err := r.Patch(ctx, owned, client.MergeFrom(item)); err != nil {
+			log.Error(err, "an error occurred setting the request as the owner of the review", "name", item.GetName())
+			return ctrl.Result{}, err
+		}
+	}
+
+	status.Review = &v1alpha1.BreakGlassReviewRecord{
+		Reviewer:   review.GetReviewer(),
+		Outcome:    review.GetOutcome(),
+		Comment:    review.GetComment(),
+		ReviewedAt: review.GetCreationTimestamp(),
+	}
+	status.State = v1alpha1.RequestStateApproved
+
+	condition := metav1.Condition{
+		Type:    "Reviewed",
+		Status:  metav1.ConditionTrue,
+		Reason:  "UsageAcceptable",
+		Message: fmt.Sprintf("%s reviewed the break-glass access as acceptable", review.GetReviewer()),
+	}
+	if review.GetOutcome() == v1alpha1.ReviewOutcomeFlagged {
+		condition.Reason = "UsageFlagged"
+		condition.Message = fmt.Sprintf("%s flagged the break-glass access", review.GetReviewer())
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "Reviewed", "BreakGlassFlagged",
+			"%s flagged the break-glass access of %s for incident %s: %s",
+			review.GetReviewer(), spec.Subject, spec.BreakGlass.Incident, review.GetComment())
+	} else {
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Reviewed", "BreakGlassAcceptable",
+			"%s reviewed the break-glass access of %s for incident %s as acceptable",
+			review.GetReviewer(), spec.Subject, spec.BreakGlass.Incident)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	log.Info("break-glass request reviewed", "name", obj.GetName(), "reviewer", review.GetReviewer(), "outcome", review.GetOutcome())
+	metrics.BreakGlassReviews.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetSubject(), string(review.GetOutcome())).Inc()
+	r.updateRequestStatusMetric(obj, status.State)
+
+	active, err := r.hasActiveGrant(ctx, obj, status)
+	if err != nil {
+		log.Error(err, "an error occurred fetching the grant of the reviewed request", "name", obj.GetName())
+		return ctrl.Result{}, err
+	}
+	if !active {
+		log.Info("the grant of the reviewed request has ended, deleting the request", "name", obj.GetName())
+		return ctrl.Result{}, r.expireRequest(ctx, obj)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// hasActiveGrant returns true if the grant created for the request still
+// exists and hasn't expired
+func (r *RequestProcessor) hasActiveGrant(
+	ctx context.Context,
+	obj common.AccessRequestObject,
+	status *v1alpha1.AccessRequestStatus,
+) (bool, error) {
+	var grant common.AccessGrantObject
+	if obj.GetScope() == v1alpha1.RequestScopeCluster {
+		grant = &v1alpha1.ClusterAccessGrant{}
+	} else {
+		grant = &v1alpha1.AccessGrant{}
+	}
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), grant); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// A grant whose status hasn't been populated yet was only just created
+	grantStatus := grant.GetStatus()
+	if grantStatus.RequestId != "" && grantStatus.RequestId != status.RequestId {
+		return false, nil
+	}
+	return grantStatus.ExpiredAt.IsZero() && grant.GetDeletionTimestamp().IsZero(), nil
+}
+
+// recordApprovals converts approvals to their status representation, ordered
+// by the time they were given
+func recordApprovals(approvals []policy.Approval, groups map[string]string) []v1alpha1.AccessRequestApproval {
+	if len(approvals) == 0 {
+		return nil
+	}
+
+	records := make([]v1alpha1.AccessRequestApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		records = append(records, v1alpha1.AccessRequestApproval{
+			Approver:   approval.Approver,
+			ApprovedAt: approval.ApprovedAt,
+			Group:      groups[approval.Approver],
+		})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].ApprovedAt.Equal(&records[j].ApprovedAt) {
+			return records[i].ApprovedAt.Before(&records[j].ApprovedAt)
+		}
+		return records[i].Approver < records[j].Approver
+	})
+
+	return records
+}
+
 func (r *RequestProcessor) expireRequest(
 	ctx context.Context,
 	obj common.AccessRequestObject,
@@ -359,6 +782,7 @@ func (r *RequestProcessor) cleanupResponses(ctx context.Context, obj common.Acce
 func (r *RequestProcessor) createGrant(
 	ctx context.Context,
 	obj common.AccessRequestObject,
+	matchedPolicy *v1alpha1.SubjectPolicy,
 	status *v1alpha1.AccessRequestStatus,
 	approvers []string,
 ) error {
@@ -373,12 +797,36 @@ func (r *RequestProcessor) createGrant(
 		Request:   reqName,
 		RequestId: status.RequestId,
 
-		Subject:    spec.Subject,
-		ApprovedBy: approvers,
+		Subject:     spec.Subject,
+		ApprovedBy:  approvers,
+		GrantTarget: spec.GrantTarget.DeepCopy(),
+		Delegation:  status.Delegation.DeepCopy(),
+
+		IncidentMode: status.IncidentMode,
+
+		Policy:      status.ResolvedPolicy,
+		PolicyScope: status.ResolvedPolicyScope,
 
 		Role:        spec.Role,
 		Permissions: spec.Permissions,
-		Duration:    spec.Duration,
+		Duration:    policy.RequestDuration(matchedPolicy, spec.Duration),
+
+		Justification:       spec.Justification,
+		JustificationFields: spec.JustificationFields,
+
+		Schedule: matchedPolicy.Schedule.DeepCopy(),
+	}
+
+	if spec.BreakGlass != nil {
+		grantBaseStatus.Incident = spec.BreakGlass.Incident
+	}
+
+	if spec.StartAt != nil {
+		grantBaseStatus.StartAt = *spec.StartAt.DeepCopy()
+	}
+
+	if retention := policy.QuotaRetention(matchedPolicy.Quota); retention > 0 {
+		grantBaseStatus.RetainAfterExpiry = retention.String()
 	}
 
 	var grant common.AccessGrantObject
@@ -388,7 +836,8 @@ func (r *RequestProcessor) createGrant(
 			ObjectMeta: metav1.ObjectMeta{Name: reqName, Labels: labels},
 		}
 	} else {
-		if spec.Role.Kind != common.RoleKindRole {
+		// A RoleBinding can reference a ClusterRole to grant it within the namespace
+		if spec.Role.Kind != common.RoleKindRole && spec.Role.Kind != common.RoleKindCluster {
 			return fmt.Errorf("invalid role kind for namespace scoped grant: %s", spec.Role.Kind)
 		}
 
@@ -402,7 +851,17 @@ func (r *RequestProcessor) createGrant(
 	}
 
 	if err := r.Create(ctx, grant); err != nil {
-		return err
+		if !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		// A grant retained for quota tracking may still hold the name, replace it
+		replaced, replaceErr := r.replaceRetainedGrant(ctx, grant)
+		if replaceErr != nil {
+			return replaceErr
+		}
+		if !replaced {
+			return err
+		}
 	}
 
 	original := grant.DeepCopyObject().(client.Object)
@@ -417,6 +876,26 @@ func (r *RequestProcessor) createGrant(
 	return nil
 }
 
+func (r *RequestProcessor) replaceRetainedGrant(ctx context.Context, grant common.AccessGrantObject) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	existing := grant.DeepCopyObject().(common.AccessGrantObject)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(grant), existing); err != nil {
+		return false, err
+	}
+
+	if existing.GetStatus().ExpiredAt.IsZero() {
+		return false, nil
+	}
+
+	log.Info("replacing retained grant from a previous request", "name", existing.GetName(), "requestId", existing.GetStatus().RequestId)
+	if err := r.Delete(ctx, existing); err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+
+	return true, r.Create(ctx, grant)
+}
+
 func (r *RequestProcessor) updateRequestStatusMetric(obj common.AccessRequestObject, state v1alpha1.RequestState) {
 	var metricValue float64
 	switch state {
@@ -424,6 +903,8 @@ func (r *RequestProcessor) updateRequestStatusMetric(obj common.AccessRequestObj
 		metricValue = metrics.MetricStateApproved
 	case v1alpha1.RequestStateDenied:
 		metricValue = metrics.MetricStateDenied
+	case v1alpha1.RequestStatePendingReview:
+		metricValue = metrics.MetricStatePendingReview
 	default:
 		metricValue = metrics.MetricStatePending
 	}
@@ -0,0 +1,406 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/metrics"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+)
+
+type ScheduleProcessor struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Recorder       events.EventRecorder
+	PolicyManager  *policy.PolicyManager
+	PolicyResolver *policy.PolicyResolver
+}
+
+func (r *ScheduleProcessor) ReconcileSchedule(ctx context.Context, obj common.AccessScheduleObject) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	originalStatus := *obj.GetStatus().DeepCopy()
+	status := obj.GetStatus().DeepCopy()
+
+	base := obj.DeepCopyObject().(client.Object)
+
+	defer func() {
+		if obj.GetDeletionTimestamp().IsZero() {
+			if !equality.Semantic.DeepEqual(originalStatus, *status) {
+				obj.SetStatus(status)
+
+				if err := r.Status().Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+					log.Error(err, "failed to persist status with patch")
+				}
+			}
+		}
+	}()
+
+	// The schedule's request and grants are owned by the schedule and are
+	// garbage collected with it
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if status.State == v1alpha1.ScheduleStateDenied || status.State == v1alpha1.ScheduleStateExpired {
+		return ctrl.Result{}, nil
+	}
+
+	if status.State == "" {
+		status.State = v1alpha1.ScheduleStatePending
+	}
+
+	spec := obj.GetSpec()
+
+	cron, err := policy.ParseCron(spec.Schedule, spec.TimeZone)
+	if err != nil {
+		log.Error(err, "failed to parse the schedule", "name", obj.GetName(), "schedule", spec.Schedule)
+		return ctrl.Result{}, err
+	}
+
+	if status.State == v1alpha1.ScheduleStatePending {
+		approved, err := r.handlePendingSchedule(ctx, obj, status, cron)
+		if err != nil || !approved {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.handleActiveSchedule(ctx, obj, status, cron)
+}
+
+// handlePendingSchedule creates the request the schedule is approved with and
+// follows its state. It returns true once the request has been approved.
+func (r *ScheduleProcessor) handlePendingSchedule(
+	ctx context.Context,
+	obj common.AccessScheduleObject,
+	status *v1alpha1.AccessScheduleStatus,
+	cron *policy.Cron,
+) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if status.Request == "" {
+		// The request is checked against the policies at the first occurrence
+		startAt := metav1.NewTime(cron.Next(time.Now()))
+		request := policy.ScheduleRequest(obj, &startAt)
+		if err := controllerutil.SetControllerReference(obj, request, r.Scheme); err != nil {
+			return false, fmt.Errorf("failed to set owner reference on request %s: %w", request.GetName(), err)
+		}
+
+		if err := r.Create(ctx, request); err != nil {
+			if !k8serrors.IsAlreadyExists(err) {
+				log.Error(err, "an error occurred creating the request for the schedule", "name", obj.GetName())
+				return false, err
+			}
+
+			// Only a request created for the schedule by an earlier reconcile can approve it
+			if err := r.Get(ctx, client.ObjectKeyFromObject(request), request); err != nil {
+				return false, err
+			}
+			if !metav1.IsControlledBy(request, obj) {
+				r.setRequestConflict(obj, status, request)
+				return false, nil
+			}
+		}
+
+		log.Info("created request for access schedule", "name", obj.GetName())
+		status.Request = request.GetName()
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "Approved",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RequestPending",
+			Message: fmt.Sprintf("The schedule is waiting for request %s to be approved", request.GetName()),
+		})
+		return false, nil
+	}
+
+	request := policy.ScheduleRequest(obj, nil)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(request), request); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		// Requests that expire before they're approved are deleted
+		status.State = v1alpha1.ScheduleStateExpired
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "Approved",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RequestExpired",
+			Message: fmt.Sprintf("Request %s expired before it was approved", status.Request),
+		})
+		return false, nil
+	}
+	if !metav1.IsControlledBy(request, obj) {
+		r.setRequestConflict(obj, status, request)
+		return false, nil
+	}
+
+	requestStatus := request.GetStatus()
+	switch requestStatus.State {
+	case v1alpha1.RequestStateApproved:
+		status.State = v1alpha1.ScheduleStateActive
+		status.ApprovedBy = nil
+		for _, approval := range requestStatus.Approvals {
+			status.ApprovedBy = append(status.ApprovedBy, approval.Approver)
+		}
+
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "Approved",
+			Status:  metav1.ConditionTrue,
+			Reason:  "RequestApproved",
+			Message: "The schedule was approved and grants access at each occurrence",
+		})
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, "Approved", "ScheduleApproved",
+			"Access schedule for %s approved by %s", obj.GetSpec().Subject, strings.Join(status.ApprovedBy, ", "))
+		return true, nil
+	case v1alpha1.RequestStateDenied:
+		status.State = v1alpha1.ScheduleStateDenied
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "Approved",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RequestDenied",
+			Message: fmt.Sprintf("Request %s was denied", status.Request),
+		})
+		r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "Denied", "ScheduleDenied",
+			"Access schedule for %s was denied", obj.GetSpec().Subject)
+	case v1alpha1.RequestStateExpired:
+		status.State = v1alpha1.ScheduleStateExpired
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    "Approved",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RequestExpired",
+			Message: fmt.Sprintf("Request %s expired before it was approved", status.Request),
+		})
+	}
+
+	return false, nil
+}
+
+// setRequestConflict records that a request the schedule doesn't control has
+// the name of the schedule's request. The schedule can't be approved with it,
+// since it may have been approved for different access.
+func (r *ScheduleProcessor) setRequestConflict(
+	obj common.AccessScheduleObject,
+	status *v1alpha1.AccessScheduleStatus,
+	request client.Object,
+) {
+	status.Request = ""
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    "Approved",
+		Status:  metav1.ConditionFalse,
+		Reason:  "RequestConflict",
+		Message: fmt.Sprintf("Request %s already exists and wasn't created for the schedule, recreate the schedule with another name", request.GetName()),
+	})
+	r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "Failed", "RequestConflict",
+		"Request %s already exists and wasn't created for the access schedule", request.GetName())
+}
+
+// handleActiveSchedule creates a grant for the current occurrence of an
+// approved schedule and requeues for the next one
+func (r *ScheduleProcessor) handleActiveSchedule(
+	ctx context.Context,
+	obj common.AccessScheduleObject,
+	status *v1alpha1.AccessScheduleStatus,
+	cron *policy.Cron,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	spec := obj.GetSpec()
+
+	duration, err := time.ParseDuration(spec.Duration)
+	if err != nil {
+		log.Error(err, "failed to parse duration string", "name", obj.GetName(), "duration", spec.Duration)
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	if start, active := cron.ActiveOccurrence(duration, now); active && start.After(status.LastOccurrence.Time) {
+		if err := r.handleOccurrence(ctx, obj, status, start, now); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	next := cron.Next(now)
+	if next.IsZero() {
+		status.NextOccurrence = metav1.Time{}
+		return ctrl.Result{}, nil
+	}
+	status.NextOccurrence = metav1.NewTime(next)
+
+	return ctrl.Result{RequeueAfter: time.Until(next) + time.Second}, nil
+}
+
+// handleOccurrence grants access for the occurrence starting at start. The
+// schedule is checked against the policies and quota again, and the
+// occurrence is skipped when they no longer allow it.
+func (r *ScheduleProcessor) handleOccurrence(
+	ctx context.Context,
+	obj common.AccessScheduleObject,
+	status *v1alpha1.AccessScheduleStatus,
+	start time.Time,
+	now time.Time,
+) error {
+	log := logf.FromContext(ctx)
+	spec := obj.GetSpec()
+
+	startAt := metav1.NewTime(start)
+	request := policy.ScheduleRequest(obj, &startAt)
+
+	matchedPolicy, trace := r.PolicyResolver.ResolveWithTrace(ctx, request, r.PolicyManager.GetSnapshot())
+	if matchedPolicy == nil {
+		r.skipOccurrence(obj, status, start, "PolicyNotMatched",
+			fmt.Sprintf("the schedule does not match an access policy: %s", strings.Join(trace.Reasons(), "; ")))
+		return nil
+	}
+	policySpec := matchedPolicy.GetPolicy()
+
+	violation, err := policy.CheckQuota(ctx, r.Client, request, policySpec.Quota, now)
+	if err != nil {
+		log.Error(err, "an error occurred checking the quota for the schedule", "name", obj.GetName())
+		return err
+	}
+	if violation != "" {
+		r.skipOccurrence(obj, status, start, "QuotaExceeded", violation)
+		return nil
+	}
+
+	grantStatus := v1alpha1.AccessGrantStatus{
+		Request:   status.Request,
+		RequestId: fmt.Sprintf("%s-%d", obj.GetUID(), start.Unix()),
+
+		Subject:     spec.Subject,
+		ApprovedBy:  status.ApprovedBy,
+		GrantTarget: spec.GrantTarget.DeepCopy(),
+
+		Policy:      matchedPolicy.GetName(),
+		PolicyScope: matchedPolicy.GetScope(),
+
+		Role:        spec.Role,
+		Permissions: spec.Permissions,
+		Duration:    policy.RequestDuration(&policySpec, spec.Duration),
+
+		Justification:       spec.Justification,
+		JustificationFields: spec.JustificationFields,
+
+		Schedule: policySpec.Schedule.DeepCopy(),
+
+		IncidentMode: policy.IncidentMode(matchedPolicy),
+
+		AccessSchedule: obj.GetName(),
+		StartAt:        startAt,
+	}
+
+	if retention := policy.QuotaRetention(policySpec.Quota); retention > 0 {
+		grantStatus.RetainAfterExpiry = retention.String()
+	}
+
+	grant, err := r.createGrant(ctx, obj, start, &grantStatus)
+	if err != nil {
+		log.Error(err, "an error occurred creating the access grant for the schedule", "name", obj.GetName(), "occurrence", start)
+		return err
+	}
+
+	log.Info("granted access for schedule occurrence", "name", obj.GetName(), "grant", grant.GetName(), "occurrence", start)
+	status.LastOccurrence = startAt
+	status.LastGrant = grant.GetName()
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    "OccurrenceGranted",
+		Status:  metav1.ConditionTrue,
+		Reason:  "AccessGranted",
+		Message: fmt.Sprintf("Access was granted for the occurrence at %s by grant %s", start.Format(time.RFC3339), grant.GetName()),
+	})
+
+	metrics.ScheduledGrants.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetName(), spec.Subject, "granted").Inc()
+
+	return nil
+}
+
+func (r *ScheduleProcessor) skipOccurrence(
+	obj common.AccessScheduleObject,
+	status *v1alpha1.AccessScheduleStatus,
+	start time.Time,
+	reason string,
+	message string,
+) {
+	spec := obj.GetSpec()
+
+	status.LastOccurrence = metav1.NewTime(start)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    "OccurrenceGranted",
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: fmt.Sprintf("The occurrence at %s was skipped: %s", start.Format(time.RFC3339), message),
+	})
+	r.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, "Skipped", "OccurrenceSkipped",
+		"Access for %s at %s was not granted: %s", spec.Subject, start.Format(time.RFC3339), message)
+
+	metrics.ScheduledGrants.WithLabelValues(string(obj.GetScope()), obj.GetNamespace(), obj.GetName(), spec.Subject, "skipped").Inc()
+}
+
+// createGrant creates the grant for the occurrence starting at start, named
+// after the schedule and the occurrence
+func (r *ScheduleProcessor) createGrant(
+	ctx context.Context,
+	obj common.AccessScheduleObject,
+	start time.Time,
+	grantStatus *v1alpha1.AccessGrantStatus,
+) (common.AccessGrantObject, error) {
+	name := fmt.Sprintf("%s-%d", obj.GetName(), start.Unix())
+	labels := common.CommonLabels()
+
+	var grant common.AccessGrantObject
+	if obj.GetScope() == v1alpha1.RequestScopeCluster {
+		grant = &v1alpha1.ClusterAccessGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		}
+	} else {
+		// A RoleBinding can reference a ClusterRole to grant it within the namespace
+		if kind := grantStatus.Role.Kind; grantStatus.Role.Name != "" && kind != common.RoleKindRole && kind != common.RoleKindCluster {
+			return nil, fmt.Errorf("invalid role kind for namespace scoped grant: %s", kind)
+		}
+
+		grant = &v1alpha1.AccessGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: obj.GetNamespace(), Name: name, Labels: labels},
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(obj, grant, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference on Grant %s: %w", name, err)
+	}
+
+	if err := r.Create(ctx, grant); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(grant), grant); err != nil {
+			return nil, err
+		}
+		// The status of a grant created by an earlier reconcile may not have been set
+		if grant.GetStatus().RequestId != "" {
+			return grant, nil
+		}
+	}
+
+	original := grant.DeepCopyObject().(client.Object)
+	grant.SetStatus(grantStatus)
+
+	if err := r.Status().Patch(ctx, grant, client.MergeFrom(original)); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
@@ -0,0 +1,151 @@
+package ticket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// ErrRejected is returned when the endpoint doesn't confirm the ticket
+var ErrRejected = errors.New("ticket rejected")
+
+// ValidationRequest is sent to the ticket validation endpoint
+type ValidationRequest struct {
+	Ticket      string              `json:"ticket"`
+	Subject     string              `json:"subject"`
+	Groups      []string            `json:"groups,omitempty"`
+	Scope       string              `json:"scope"`
+	Namespace   string              `json:"namespace,omitempty"`
+	Role        *rbacv1.RoleRef     `json:"role,omitempty"`
+	Permissions []rbacv1.PolicyRule `json:"permissions,omitempty"`
+	Duration    string              `json:"duration"`
+}
+
+// ValidationResponse is returned by the ticket validation endpoint. An unknown
+// ticket can also be reported with a 404 status.
+type ValidationResponse struct {
+	Exists   bool   `json:"exists"`
+	Open     bool   `json:"open"`
+	Assignee string `json:"assignee"`
+	URL      string `json:"url,omitempty"`
+	Title    string `json:"title,omitempty"`
+}
+
+// Validator checks ticket references with an external HTTP endpoint
+type Validator struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func NewValidator(url string, timeout time.Duration) *Validator {
+	return &Validator{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Validate checks the ticket referenced by the request. Errors wrapping
+// ErrRejected mean the ticket isn't acceptable, any other error means the
+// endpoint couldn't be queried.
+func (v *Validator) Validate(
+	ctx context.Context,
+	req common.AccessRequestObject,
+	validation *accessv1alpha1.TicketValidation,
+) (*accessv1alpha1.TicketStatus, error) {
+	spec := req.GetSpec()
+
+	field := validation.Field
+	if field == "" {
+		field = "ticket"
+	}
+
+	reference := spec.JustificationFields[field]
+	if reference == "" {
+		return nil, fmt.Errorf("%w: the policy requires a ticket reference in justification field %q", ErrRejected, field)
+	}
+
+	if v == nil || v.URL == "" {
+		return nil, fmt.Errorf("the policy requires ticket validation but no ticket validation endpoint is configured")
+	}
+
+	payload := ValidationRequest{
+		Ticket:      reference,
+		Subject:     spec.Subject,
+		Groups:      spec.Groups,
+		Scope:       string(req.GetScope()),
+		Namespace:   req.GetNamespace(),
+		Permissions: spec.Permissions,
+		Duration:    spec.Duration,
+	}
+	if spec.Role.Name != "" {
+		payload.Role = &spec.Role
+	}
+
+	resp, err := v.post(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !resp.Exists:
+		return nil, fmt.Errorf("%w: ticket %s does not exist", ErrRejected, reference)
+	case !resp.Open:
+		return nil, fmt.Errorf("%w: ticket %s is not open", ErrRejected, reference)
+	case resp.Assignee != spec.Subject:
+		return nil, fmt.Errorf("%w: ticket %s is not assigned to %s", ErrRejected, reference, spec.Subject)
+	}
+
+	return &accessv1alpha1.TicketStatus{
+		Reference: reference,
+		URL:       resp.URL,
+		Title:     resp.Title,
+	}, nil
+}
+
+func (v *Validator) post(ctx context.Context, payload ValidationRequest) (*ValidationResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ticket validation request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ticket validation request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := v.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ticket validation endpoint: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return &ValidationResponse{Exists: false}, nil
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 512))
+		return nil, fmt.Errorf("ticket validation endpoint returned %s: %s", httpResp.Status, bytes.TrimSpace(msg))
+	}
+
+	resp := &ValidationResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode ticket validation response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidate(t *testing.T) {
+	tickets := map[string]ValidationResponse{
+		"INC-1": {Exists: true, Open: true, Assignee: "alice", URL: "https://tickets.example.com/INC-1", Title: "Fix deploys"},
+		"INC-2": {Exists: true, Open: false, Assignee: "alice"},
+		"INC-3": {Exists: true, Open: true, Assignee: "bob"},
+	}
+
+	var received ValidationRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if received.Ticket == "BROKEN" {
+			http.Error(w, "backend unavailable", http.StatusBadGateway)
+			return
+		}
+		resp, ok := tickets[received.Ticket]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	validator := NewValidator(server.URL, 5*time.Second)
+	validation := &accessv1alpha1.TicketValidation{Field: "ticket"}
+
+	request := func(ticket string) *accessv1alpha1.AccessRequest {
+		req := &accessv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "default"},
+			Spec: accessv1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+					Subject:  "alice",
+					Duration: "10m",
+					Role:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "edit"},
+				},
+			},
+		}
+		if ticket != "" {
+			req.Spec.JustificationFields = map[string]string{"ticket": ticket}
+		}
+		return req
+	}
+
+	tests := []struct {
+		name         string
+		ticket       string
+		wantRejected bool
+		wantErr      bool
+	}{
+		{name: "open ticket assigned to the requester", ticket: "INC-1"},
+		{name: "missing ticket reference", ticket: "", wantRejected: true},
+		{name: "unknown ticket", ticket: "INC-404", wantRejected: true},
+		{name: "closed ticket", ticket: "INC-2", wantRejected: true},
+		{name: "ticket assigned to someone else", ticket: "INC-3", wantRejected: true},
+		{name: "endpoint error", ticket: "BROKEN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := validator.Validate(context.Background(), request(tt.ticket), validation)
+
+			switch {
+			case tt.wantRejected:
+				if !errors.Is(err, ErrRejected) {
+					t.Fatalf("expected the ticket to be rejected, got %v", err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.Is(err, ErrRejected) {
+					t.Fatalf("expected an endpoint error, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if status.URL != tickets[tt.ticket].URL || status.Title != tickets[tt.ticket].Title {
+					t.Errorf("unexpected ticket status: %+v", status)
+				}
+				if received.Subject != "alice" || received.Role == nil || received.Role.Name != "edit" {
+					t.Errorf("unexpected validation request: %+v", received)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateWithoutEndpoint(t *testing.T) {
+	var validator *Validator
+
+	req := &accessv1alpha1.AccessRequest{
+		Spec: accessv1alpha1.AccessRequestSpec{
+			AccessRequestBaseSpec: accessv1alpha1.AccessRequestBaseSpec{
+				Subject:             "alice",
+				JustificationFields: map[string]string{"ticket": "INC-1"},
+			},
+		},
+	}
+
+	_, err := validator.Validate(context.Background(), req, &accessv1alpha1.TicketValidation{})
+	if err == nil || errors.Is(err, ErrRejected) {
+		t.Fatalf("expected a configuration error, got %v", err)
+	}
+}
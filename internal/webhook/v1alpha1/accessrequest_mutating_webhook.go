@@ -6,22 +6,31 @@ import (
 	"net/http"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
 	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // +kubebuilder:webhook:path=/mutate-access-antware-xyz-v1alpha1-accessrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=accessrequests,verbs=create;update,versions=v1alpha1,name=maccessrequest-v1alpha1.kb.io,admissionReviewVersions=v1
 
 type AccessRequestMutator struct {
-	decoder admission.Decoder
+	decoder        admission.Decoder
+	client         client.Client
+	PolicyManager  *policy.PolicyManager
+	PolicyResolver *policy.PolicyResolver
 }
 
-func SetupAccessRequestMutatingWebhookWithManager(mgr ctrl.Manager) {
+func SetupAccessRequestMutatingWebhookWithManager(mgr ctrl.Manager, policyManager, clusterPolicyManager *policy.PolicyManager) {
 	mgr.GetWebhookServer().Register(
 		"/mutate-access-antware-xyz-v1alpha1-accessrequest",
 		&admission.Webhook{Handler: &AccessRequestMutator{
-			decoder: admission.NewDecoder(mgr.GetScheme()),
+			decoder:        admission.NewDecoder(mgr.GetScheme()),
+			client:         mgr.GetClient(),
+			PolicyManager:  policyManager,
+			PolicyResolver: &policy.PolicyResolver{ClusterPolicies: clusterPolicyManager, Client: mgr.GetClient()},
 		}},
 	)
 }
@@ -33,9 +42,44 @@ func (m *AccessRequestMutator) Handle(ctx context.Context, req admission.Request
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	if req.Operation == admissionv1.Create {
+	// Requests for access schedules are created by the controller for the schedule's subject
+	if req.Operation == admissionv1.Create && obj.Spec.AccessSchedule == "" {
 		obj.Spec.Subject = req.UserInfo.Username
 		obj.Spec.Groups = req.UserInfo.Groups
+		obj.Spec.Delegator = ""
+		obj.Spec.DelegatorGroups = nil
+
+		// Delegated requests are made by the delegator for the beneficiary
+		if obj.Spec.OnBehalfOf != "" {
+			obj.Spec.Subject = obj.Spec.OnBehalfOf
+			obj.Spec.Delegator = req.UserInfo.Username
+			// The delegator's groups must not authorize access for the subject
+			obj.Spec.DelegatorGroups = req.UserInfo.Groups
+			obj.Spec.Groups = nil
+		}
+
+		// Extensions request the grant's access again
+		if obj.Spec.Extends != "" {
+			if grant, err := policy.ExtendedGrant(ctx, m.client, obj); err == nil {
+				grantStatus := grant.GetStatus()
+				obj.Spec.Role = grantStatus.Role
+				obj.Spec.Permissions = grantStatus.Permissions
+				obj.Spec.GrantTarget = grantStatus.GrantTarget
+			}
+		}
+
+		// ServiceAccounts default to the request's namespace
+		if target := obj.Spec.GrantTarget; target != nil && target.Kind == rbacv1.ServiceAccountKind && target.Namespace == "" {
+			target.Namespace = req.Namespace
+		}
+
+		// Default the duration from the policy the request matches
+		if obj.Spec.Duration == "" {
+			if matched := m.PolicyResolver.Resolve(ctx, obj, m.PolicyManager.GetSnapshot()); matched != nil {
+				policySpec := matched.GetPolicy()
+				obj.Spec.Duration = policy.RequestDuration(&policySpec, "")
+			}
+		}
 	}
 
 	marshaled, err := json.Marshal(obj)
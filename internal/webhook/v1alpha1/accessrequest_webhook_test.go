@@ -17,11 +17,21 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
-	// TODO (user): Add any additional imports if needed
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
 )
 
 var _ = Describe("AccessRequest Webhook", func() {
@@ -61,26 +71,68 @@ var _ = Describe("AccessRequest Webhook", func() {
 	})
 
 	Context("When creating or updating AccessRequest under Validating Webhook", func() {
-		// TODO (user): Add logic for validating webhooks
-		// Example:
-		// It("Should deny creation if a required field is missing", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = ""
-		//     Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
-		// })
-		//
-		// It("Should admit creation if all required fields are present", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = "valid_value"
-		//     Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
-		// })
-		//
-		// It("Should validate updates correctly", func() {
-		//     By("simulating a valid update scenario")
-		//     oldObj.SomeRequiredField = "updated_value"
-		//     obj.SomeRequiredField = "updated_value"
-		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
-		// })
+		var request func() admission.Request
+
+		BeforeEach(func() {
+			policyManager := policy.NewPolicyManager()
+			policyManager.Update([]common.AccessPolicyObject{&accessv1alpha1.AccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "debug",
+					Namespace: "default",
+				},
+				Spec: accessv1alpha1.AccessPolicySpec{
+					SubjectPolicy: accessv1alpha1.SubjectPolicy{
+						Requesters:        []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+						RequiredApprovals: 1,
+						AllowedRoles:      []rbacv1.RoleRef{{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "edit"}},
+						Approvers:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+						MaxDuration:       "1h",
+					},
+				},
+			}})
+
+			validator.decoder = admission.NewDecoder(scheme.Scheme)
+			validator.PolicyManager = policyManager
+			validator.PolicyResolver = &policy.PolicyResolver{}
+
+			obj.Name = "debug"
+			obj.Namespace = "default"
+			obj.Spec.Subject = "alice"
+			obj.Spec.Role = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: common.RoleKindRole, Name: "edit"}
+			obj.Spec.Duration = "1h"
+
+			request = func() admission.Request {
+				raw, err := json.Marshal(obj)
+				Expect(err).NotTo(HaveOccurred())
+				return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+					UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+				}}
+			}
+		})
+
+		It("Should deny creation without a justification", func() {
+			By("simulating a request with an empty justification")
+			resp := validator.Handle(ctx, request())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("A justification is required."))
+		})
+
+		It("Should deny creation with a blank justification", func() {
+			By("simulating a request with a justification of only whitespace")
+			obj.Spec.Justification = "  \t "
+			resp := validator.Handle(ctx, request())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal("A justification is required."))
+		})
+
+		It("Should admit creation with a justification", func() {
+			By("simulating a request with a justification")
+			obj.Spec.Justification = "Investigating INC-1234"
+			resp := validator.Handle(ctx, request())
+			Expect(resp.Allowed).To(BeTrue(), resp.Result.Message)
+		})
 	})
 
 })
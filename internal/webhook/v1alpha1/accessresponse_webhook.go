@@ -4,13 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"slices"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
 	"github.com/itsthatdude/jit-access-controller/internal/utils"
 	admissionv1 "k8s.io/api/admission/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,7 +29,7 @@ type AccessResponseValidator struct {
 	PolicyResolver         *policy.PolicyResolver
 }
 
-func SetupAccessResponseWebhookWithManager(mgr ctrl.Manager, namespace, serviceAccount string, frontendServiceAccount string, policyManager *policy.PolicyManager) {
+func SetupAccessResponseWebhookWithManager(mgr ctrl.Manager, namespace, serviceAccount string, frontendServiceAccount string, policyManager, clusterPolicyManager *policy.PolicyManager) {
 	ctx := context.Background()
 	indexer := mgr.GetFieldIndexer()
 	log := logf.FromContext(ctx)
@@ -65,7 +63,7 @@ func SetupAccessResponseWebhookWithManager(mgr ctrl.Manager, namespace, serviceA
 			serviceAccount:         serviceAccount,
 			frontendServiceAccount: frontendServiceAccount,
 			PolicyManager:          policyManager,
-			PolicyResolver:         &policy.PolicyResolver{},
+			PolicyResolver:         &policy.PolicyResolver{ClusterPolicies: clusterPolicyManager, Client: mgr.GetClient()},
 		}},
 	)
 }
@@ -96,6 +94,15 @@ func (v *AccessResponseValidator) Handle(ctx context.Context, req admission.Requ
 		return admission.Denied(fmt.Sprintf("an error occurred fetching the referenced AccessRequest: %s", err))
 	}
 
+	// Revocations end the grant of an approved request
+	if req.Operation == admissionv1.Create && obj.Spec.Response == v1alpha1.ResponseStateRevoked {
+		policies := v.PolicyManager.GetSnapshot()
+		if v.PolicyResolver.ClusterPolicies != nil {
+			policies = append(policies, v.PolicyResolver.ClusterPolicies.GetSnapshot()...)
+		}
+		return validateRevocation(ctx, v.client, request, policies, req.UserInfo, isFrontend)
+	}
+
 	existingResponses := v1alpha1.AccessResponseList{}
 	fieldSelector := fields.AndSelectors(
 		fields.OneTermEqualSelector("spec.requestRef", obj.Spec.RequestRef),
@@ -110,7 +117,7 @@ func (v *AccessResponseValidator) Handle(ctx context.Context, req admission.Requ
 	}
 
 	policies := v.PolicyManager.GetSnapshot()
-	matched_policy := v.PolicyResolver.Resolve(request, policies)
+	matched_policy := v.PolicyResolver.Resolve(ctx, request, policies)
 
 	if matched_policy == nil {
 		return admission.Denied(fmt.Sprintf("the request %s does not match an access policy in namespace '%s'", req.Name, req.Namespace))
@@ -118,27 +125,23 @@ func (v *AccessResponseValidator) Handle(ctx context.Context, req admission.Requ
 
 	policySpec := matched_policy.GetPolicy()
 
-	if !policySpec.AllowSelfApproval && request.Spec.Subject == obj.Spec.Approver {
+	// Extensions are approved under the policy's extension rules
+	if request.Spec.Extends != "" {
+		policySpec = policy.ExtensionApprovalPolicy(policySpec)
+	}
+
+	if !policySpec.AllowSelfApproval && policy.IsSelfApproval(request.Spec.AccessRequestBaseSpec, obj.Spec.Approver) {
 		return admission.Denied("The approver can not be the same as the subject of the request.")
 	}
 
 	switch req.Operation {
 	case admissionv1.Create:
-		userNames := []string{}
-		groupNames := []string{}
-		for _, approver := range policySpec.Approvers {
-			switch approver.Kind {
-			case rbacv1.UserKind:
-				userNames = append(userNames, approver.Name)
-			case rbacv1.GroupKind:
-				groupNames = append(groupNames, approver.Name)
-			}
-		}
+		approvers, stage := policy.CurrentApprovers(&policySpec, &request.Status)
 
-		group_matched := utils.SliceOverlaps(groupNames, req.UserInfo.Groups)
-		user_matched := slices.Contains(userNames, req.UserInfo.Username)
-
-		if !group_matched && !user_matched && !isFrontend {
+		if !isFrontend && !policy.MatchesApprovers(approvers, req.UserInfo.Username, req.UserInfo.Groups) {
+			if stage != "" {
+				return admission.Denied(fmt.Sprintf("user %s is not an approver for the current approval stage %q of the matched policy", req.UserInfo.Username, stage))
+			}
 			return admission.Denied(fmt.Sprintf("user %s is not in the list of approvers for the matched policy", req.UserInfo.Username))
 		}
 
@@ -0,0 +1,81 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validateSchedule checks a new access schedule is made by its subject and
+// would be allowed by a policy at its first occurrence
+func validateSchedule(
+	ctx context.Context,
+	schedule common.AccessScheduleObject,
+	user authenticationv1.UserInfo,
+	resolver *policy.PolicyResolver,
+	policies []common.AccessPolicyObject,
+	ticketValidator *ticket.Validator,
+) admission.Response {
+	spec := schedule.GetSpec()
+
+	if spec.Subject != user.Username {
+		return admission.Denied("The subject must be the same as the user creating the schedule.")
+	}
+	if !reflect.DeepEqual(spec.Groups, user.Groups) {
+		return admission.Denied("The subject's groups must be the same as the user creating the schedule.")
+	}
+	if spec.Role.Name == "" && len(spec.Permissions) == 0 {
+		return admission.Denied("either Role or Permissions needs to be set")
+	}
+	if strings.TrimSpace(spec.Justification) == "" {
+		return admission.Denied("A justification is required.")
+	}
+
+	cron, err := policy.ParseCron(spec.Schedule, spec.TimeZone)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	if duration, err := time.ParseDuration(spec.Duration); err != nil || duration <= 0 {
+		return admission.Denied(fmt.Sprintf("invalid duration %q", spec.Duration))
+	}
+
+	next := cron.Next(time.Now())
+	if next.IsZero() {
+		return admission.Denied(fmt.Sprintf("schedule %q never occurs", spec.Schedule))
+	}
+
+	// Policies are checked at the first occurrence, so access windows apply
+	startAt := metav1.NewTime(next)
+	request := policy.ScheduleRequest(schedule, &startAt)
+
+	matched, trace := resolver.ResolveWithTrace(ctx, request, policies)
+	if matched == nil {
+		reasons := trace.Reasons()
+		if len(reasons) > 0 {
+			return admission.Denied(fmt.Sprintf("access schedule did not match a policy: %s", strings.Join(reasons, "; ")))
+		}
+		return admission.Denied("access schedule did not match a policy")
+	}
+
+	policySpec := matched.GetPolicy()
+	if err := policy.ValidateJustification(policySpec.JustificationSchema, spec.JustificationFields); err != nil {
+		return admission.Denied(fmt.Sprintf("invalid justification: %s", err))
+	}
+
+	if policySpec.TicketValidation != nil {
+		if _, err := ticketValidator.Validate(ctx, request, policySpec.TicketValidation); err != nil {
+			return admission.Denied(err.Error())
+		}
+	}
+
+	return admission.Allowed("valid")
+}
@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-access-antware-xyz-v1alpha1-accessschedule,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=accessschedules,verbs=create;update,versions=v1alpha1,name=maccessschedule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type AccessScheduleMutator struct {
+	decoder admission.Decoder
+}
+
+func SetupAccessScheduleMutatingWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(
+		"/mutate-access-antware-xyz-v1alpha1-accessschedule",
+		&admission.Webhook{Handler: &AccessScheduleMutator{
+			decoder: admission.NewDecoder(mgr.GetScheme()),
+		}},
+	)
+}
+
+func (m *AccessScheduleMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &v1alpha1.AccessSchedule{}
+
+	if err := m.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Create {
+		obj.Spec.Subject = req.UserInfo.Username
+		obj.Spec.Groups = req.UserInfo.Groups
+
+		// ServiceAccounts default to the schedule's namespace
+		if target := obj.Spec.GrantTarget; target != nil && target.Kind == rbacv1.ServiceAccountKind && target.Namespace == "" {
+			target.Namespace = req.Namespace
+		}
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
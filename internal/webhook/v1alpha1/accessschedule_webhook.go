@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
+)
+
+// +kubebuilder:webhook:path=/validate-access-antware-xyz-v1alpha1-accessschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=accessschedules,verbs=create;update,versions=v1alpha1,name=vaccessschedule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type AccessScheduleValidator struct {
+	decoder         admission.Decoder
+	PolicyManager   *policy.PolicyManager
+	PolicyResolver  *policy.PolicyResolver
+	TicketValidator *ticket.Validator
+}
+
+func SetupAccessScheduleWebhookWithManager(mgr ctrl.Manager, policyManager, clusterPolicyManager *policy.PolicyManager, ticketValidator *ticket.Validator) {
+	mgr.GetWebhookServer().Register(
+		"/validate-access-antware-xyz-v1alpha1-accessschedule",
+		&admission.Webhook{Handler: &AccessScheduleValidator{
+			decoder:         admission.NewDecoder(mgr.GetScheme()),
+			PolicyManager:   policyManager,
+			PolicyResolver:  &policy.PolicyResolver{ClusterPolicies: clusterPolicyManager, Client: mgr.GetClient()},
+			TicketValidator: ticketValidator,
+		}},
+	)
+}
+
+func (v *AccessScheduleValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &accessv1alpha1.AccessSchedule{}
+
+	if err := v.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("deletion is allowed")
+	}
+
+	// The spec of a schedule is immutable, updates only change its metadata
+	if req.Operation == admissionv1.Update {
+		return admission.Allowed("valid")
+	}
+
+	return validateSchedule(ctx, obj, req.UserInfo, v.PolicyResolver, v.PolicyManager.GetSnapshot(), v.TicketValidator)
+}
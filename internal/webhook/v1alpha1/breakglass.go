@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validateReview checks the user can review a break-glass request. Reviews are
+// made by the policy's break-glass reviewers, and never by the subject or the
+// delegator who requested the access.
+func validateReview(
+	request common.AccessRequestObject,
+	policies []common.AccessPolicyObject,
+	user authenticationv1.UserInfo,
+	isFrontend bool,
+) admission.Response {
+	spec := request.GetSpec()
+
+	if spec.BreakGlass == nil {
+		return admission.Denied(fmt.Sprintf("request %s is not a break-glass request", request.GetName()))
+	}
+	if state := request.GetStatus().State; state != v1alpha1.RequestStatePendingReview {
+		return admission.Denied(fmt.Sprintf("request %s is not pending review, its state is %s", request.GetName(), state))
+	}
+	if isFrontend {
+		return admission.Allowed("valid")
+	}
+	if policy.IsSelfApproval(*spec, user.Username) {
+		return admission.Denied("The reviewer can not be the subject or the requester of the break-glass request.")
+	}
+
+	p := policy.ResolvedPolicy(request, policies)
+	if p == nil {
+		return admission.Denied(fmt.Sprintf("policy %s of request %s no longer exists", request.GetStatus().ResolvedPolicy, request.GetName()))
+	}
+	policySpec := p.GetPolicy()
+	if !policy.MatchesApprovers(policy.BreakGlassReviewers(&policySpec), user.Username, user.Groups) {
+		return admission.Denied(fmt.Sprintf("user %s is not allowed to review break-glass requests of policy %s", user.Username, p.GetName()))
+	}
+
+	return admission.Allowed("valid")
+}
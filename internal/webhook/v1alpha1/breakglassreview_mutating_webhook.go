@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/utils"
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-access-antware-xyz-v1alpha1-breakglassreview,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=breakglassreviews,verbs=create;update,versions=v1alpha1,name=mbreakglassreview-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type BreakGlassReviewMutator struct {
+	decoder                admission.Decoder
+	namespace              string
+	frontendServiceAccount string
+}
+
+func SetupBreakGlassReviewMutatingWebhookWithManager(mgr ctrl.Manager, namespace string, frontendServiceAccount string) {
+	mgr.GetWebhookServer().Register(
+		"/mutate-access-antware-xyz-v1alpha1-breakglassreview",
+		&admission.Webhook{Handler: &BreakGlassReviewMutator{
+			decoder:                admission.NewDecoder(mgr.GetScheme()),
+			namespace:              namespace,
+			frontendServiceAccount: frontendServiceAccount,
+		}},
+	)
+}
+
+func (m *BreakGlassReviewMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &v1alpha1.BreakGlassReview{}
+
+	if err := m.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	isFrontend := utils.IsController(m.namespace, m.frontendServiceAccount, req.UserInfo)
+	// Set the reviewer to the current user
+	if !isFrontend {
+		if req.Operation == admissionv1.Create {
+			obj.Spec.Reviewer = req.UserInfo.Username
+			obj.Spec.Groups = req.UserInfo.Groups
+		}
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
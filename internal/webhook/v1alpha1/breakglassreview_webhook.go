@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/utils"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-access-antware-xyz-v1alpha1-breakglassreview,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=breakglassreviews,verbs=create;update,versions=v1alpha1,name=vbreakglassreview-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type BreakGlassReviewValidator struct {
+	decoder                admission.Decoder
+	client                 client.Client
+	namespace              string
+	frontendServiceAccount string
+	PolicyManager          *policy.PolicyManager
+	ClusterPolicyManager   *policy.PolicyManager
+}
+
+func SetupBreakGlassReviewWebhookWithManager(mgr ctrl.Manager, namespace string, frontendServiceAccount string, policyManager, clusterPolicyManager *policy.PolicyManager) {
+	mgr.GetWebhookServer().Register(
+		"/validate-access-antware-xyz-v1alpha1-breakglassreview",
+		&admission.Webhook{Handler: &BreakGlassReviewValidator{
+			decoder:                admission.NewDecoder(mgr.GetScheme()),
+			client:                 mgr.GetClient(),
+			namespace:              namespace,
+			frontendServiceAccount: frontendServiceAccount,
+			PolicyManager:          policyManager,
+			ClusterPolicyManager:   clusterPolicyManager,
+		}},
+	)
+}
+
+func (v *BreakGlassReviewValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &v1alpha1.BreakGlassReview{}
+	isFrontend := utils.IsController(v.namespace, v.frontendServiceAccount, req.UserInfo)
+
+	if err := v.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("deletion is allowed")
+	}
+
+	// The spec of a review is immutable, updates only change its metadata
+	if req.Operation == admissionv1.Update {
+		return admission.Allowed("valid")
+	}
+
+	if !isFrontend && obj.Spec.Reviewer != req.UserInfo.Username {
+		return admission.Denied("The reviewer must be the user creating the review.")
+	}
+
+	request := &v1alpha1.AccessRequest{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: obj.Spec.RequestRef}, request); err != nil {
+		return admission.Denied(fmt.Sprintf("an error occurred fetching the referenced AccessRequest: %s", err))
+	}
+
+	policies := v.PolicyManager.GetSnapshot()
+	if v.ClusterPolicyManager != nil {
+		policies = append(policies, v.ClusterPolicyManager.GetSnapshot()...)
+	}
+	return validateReview(request, policies, req.UserInfo, isFrontend)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+)
+
+// +kubebuilder:webhook:path=/validate-access-antware-xyz-v1alpha1-clusteraccesspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=clusteraccesspolicies,verbs=create;update,versions=v1alpha1,name=vclusteraccesspolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type ClusterAccessPolicyValidator struct {
+	decoder       admission.Decoder
+	client        client.Client
+	PolicyManager *policy.PolicyManager
+}
+
+func SetupClusterAccessPolicyWebhookWithManager(mgr ctrl.Manager, policyManager *policy.PolicyManager) {
+	mgr.GetWebhookServer().Register(
+		"/validate-access-antware-xyz-v1alpha1-clusteraccesspolicy",
+		&admission.Webhook{Handler: &ClusterAccessPolicyValidator{
+			decoder:       admission.NewDecoder(mgr.GetScheme()),
+			client:        mgr.GetClient(),
+			PolicyManager: policyManager,
+		}},
+	)
+}
+
+func (v *ClusterAccessPolicyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("deletion is allowed")
+	}
+
+	obj := &accessv1alpha1.ClusterAccessPolicy{}
+
+	if err := v.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	return validatePolicy(ctx, v.client, v.PolicyManager, obj)
+}
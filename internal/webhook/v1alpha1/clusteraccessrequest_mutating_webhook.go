@@ -6,22 +6,30 @@ import (
 	"net/http"
 
 	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
 	admissionv1 "k8s.io/api/admission/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // +kubebuilder:webhook:path=/mutate-access-antware-xyz-v1alpha1-clusteraccessrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=clusteraccessrequests,verbs=create;update,versions=v1alpha1,name=mclusteraccessrequest-v1alpha1.kb.io,admissionReviewVersions=v1
 
 type ClusterAccessRequestMutator struct {
-	decoder admission.Decoder
+	decoder        admission.Decoder
+	client         client.Client
+	PolicyManager  *policy.PolicyManager
+	PolicyResolver *policy.PolicyResolver
 }
 
-func SetupClusterAccessRequestMutatingWebhookWithManager(mgr ctrl.Manager) {
+func SetupClusterAccessRequestMutatingWebhookWithManager(mgr ctrl.Manager, policyManager *policy.PolicyManager) {
 	mgr.GetWebhookServer().Register(
 		"/mutate-access-antware-xyz-v1alpha1-clusteraccessrequest",
 		&admission.Webhook{Handler: &ClusterAccessRequestMutator{
-			decoder: admission.NewDecoder(mgr.GetScheme()),
+			decoder:        admission.NewDecoder(mgr.GetScheme()),
+			client:         mgr.GetClient(),
+			PolicyManager:  policyManager,
+			PolicyResolver: &policy.PolicyResolver{Client: mgr.GetClient()},
 		}},
 	)
 }
@@ -33,9 +41,39 @@ func (m *ClusterAccessRequestMutator) Handle(ctx context.Context, req admission.
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	if req.Operation == admissionv1.Create {
+	// Requests for access schedules are created by the controller for the schedule's subject
+	if req.Operation == admissionv1.Create && obj.Spec.AccessSchedule == "" {
 		obj.Spec.Subject = req.UserInfo.Username
 		obj.Spec.Groups = req.UserInfo.Groups
+		obj.Spec.Delegator = ""
+		obj.Spec.DelegatorGroups = nil
+
+		// Delegated requests are made by the delegator for the beneficiary
+		if obj.Spec.OnBehalfOf != "" {
+			obj.Spec.Subject = obj.Spec.OnBehalfOf
+			obj.Spec.Delegator = req.UserInfo.Username
+			// The delegator's groups must not authorize access for the subject
+			obj.Spec.DelegatorGroups = req.UserInfo.Groups
+			obj.Spec.Groups = nil
+		}
+
+		// Extensions request the grant's access again
+		if obj.Spec.Extends != "" {
+			if grant, err := policy.ExtendedGrant(ctx, m.client, obj); err == nil {
+				grantStatus := grant.GetStatus()
+				obj.Spec.Role = grantStatus.Role
+				obj.Spec.Permissions = grantStatus.Permissions
+				obj.Spec.GrantTarget = grantStatus.GrantTarget
+			}
+		}
+
+		// Default the duration from the policy the request matches
+		if obj.Spec.Duration == "" {
+			if matched := m.PolicyResolver.Resolve(ctx, obj, m.PolicyManager.GetSnapshot()); matched != nil {
+				policySpec := matched.GetPolicy()
+				obj.Spec.Duration = policy.RequestDuration(&policySpec, "")
+			}
+		}
 	}
 
 	marshaled, err := json.Marshal(obj)
@@ -18,8 +18,11 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -28,30 +31,33 @@ import (
 
 	accessv1alpha1 "github.com/itsthatdude/jit-access-controller/api/v1alpha1"
 	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	"github.com/itsthatdude/jit-access-controller/internal/ticket"
 	"github.com/itsthatdude/jit-access-controller/internal/utils"
 )
 
 // +kubebuilder:webhook:path=/validate-access-antware-xyz-v1alpha1-clusteraccessrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=clusteraccessrequests,verbs=create;update,versions=v1alpha1,name=vclusteraccessrequest-v1alpha1.kb.io,admissionReviewVersions=v1
 
 type ClusterAccessRequestValidator struct {
-	decoder        admission.Decoder
-	client         client.Client
-	namespace      string
-	serviceAccount string
-	PolicyManager  *policy.PolicyManager
-	PolicyResolver *policy.PolicyResolver
+	decoder         admission.Decoder
+	client          client.Client
+	namespace       string
+	serviceAccount  string
+	PolicyManager   *policy.PolicyManager
+	PolicyResolver  *policy.PolicyResolver
+	TicketValidator *ticket.Validator
 }
 
-func SetupClusterAccessRequestWebhookWithManager(mgr ctrl.Manager, namespace, serviceAccount string, policyManager *policy.PolicyManager) {
+func SetupClusterAccessRequestWebhookWithManager(mgr ctrl.Manager, namespace, serviceAccount string, policyManager *policy.PolicyManager, ticketValidator *ticket.Validator) {
 	mgr.GetWebhookServer().Register(
 		"/validate-access-antware-xyz-v1alpha1-clusteraccessrequest",
 		&admission.Webhook{Handler: &ClusterAccessRequestValidator{
-			decoder:        admission.NewDecoder(mgr.GetScheme()),
-			client:         mgr.GetClient(),
-			namespace:      namespace,
-			serviceAccount: serviceAccount,
-			PolicyManager:  policyManager,
-			PolicyResolver: &policy.PolicyResolver{},
+			decoder:         admission.NewDecoder(mgr.GetScheme()),
+			client:          mgr.GetClient(),
+			namespace:       namespace,
+			serviceAccount:  serviceAccount,
+			PolicyManager:   policyManager,
+			PolicyResolver:  &policy.PolicyResolver{Client: mgr.GetClient()},
+			TicketValidator: ticketValidator,
 		}},
 	)
 }
@@ -72,25 +78,96 @@ func (v *ClusterAccessRequestValidator) Handle(ctx context.Context, req admissio
 		return admission.Allowed("jit-access-controller-manager is allowed to update access requests")
 	}
 
-	if req.Operation == admissionv1.Create {
-		if obj.Spec.Subject != req.UserInfo.Username {
-			return admission.Denied("The subject must be the same as the user creating the request.")
+	// Requests for access schedules are created by the controller for the
+	// schedule's subject, whose identity was checked when the schedule was created
+	if obj.Spec.AccessSchedule != "" && req.Operation == admissionv1.Create && !isController {
+		return admission.Denied("accessSchedule can only be set on requests created for an access schedule by the controller.")
+	}
+
+	if req.Operation == admissionv1.Create && obj.Spec.AccessSchedule == "" {
+		if obj.Spec.OnBehalfOf != "" {
+			if obj.Spec.OnBehalfOf == req.UserInfo.Username {
+				return admission.Denied("A request can't be made on behalf of the user creating it.")
+			}
+			if obj.Spec.Subject != obj.Spec.OnBehalfOf || obj.Spec.Delegator != req.UserInfo.Username {
+				return admission.Denied("The subject must be the user the request is made on behalf of, and the delegator the user creating the request.")
+			}
+			if len(obj.Spec.Groups) > 0 || !reflect.DeepEqual(obj.Spec.DelegatorGroups, req.UserInfo.Groups) {
+				return admission.Denied("The subject's groups must be empty, and the delegator's groups the same as the user creating the request.")
+			}
+		} else {
+			if obj.Spec.Subject != req.UserInfo.Username {
+				return admission.Denied("The subject must be the same as the user creating the request.")
+			}
+			if obj.Spec.Delegator != "" || len(obj.Spec.DelegatorGroups) > 0 {
+				return admission.Denied("The delegator can only be set on requests made on behalf of another user.")
+			}
+			if !reflect.DeepEqual(obj.Spec.Groups, req.UserInfo.Groups) {
+				return admission.Denied("The subject's groups must be the same as the user creating the request.")
+			}
 		}
-		if !reflect.DeepEqual(obj.Spec.Groups, req.UserInfo.Groups) {
-			return admission.Denied("The subject's groups must be the same as the user creating the request.")
+		if reason := validateStartAt(&obj.Spec.AccessRequestBaseSpec, time.Now()); reason != "" {
+			return admission.Denied(reason)
 		}
 	}
 
+	// Blank justifications aren't accepted by auditors, whatever the policy's justificationSchema
+	if req.Operation == admissionv1.Create && strings.TrimSpace(obj.Spec.Justification) == "" {
+		return admission.Denied("A justification is required.")
+	}
+
 	if obj.Spec.Role.Name == "" && len(obj.Spec.Permissions) == 0 {
 		return admission.Denied("either ClusterRole or Permissions needs to be set")
 	}
 
 	policies := v.PolicyManager.GetSnapshot()
-	matched_policy := v.PolicyResolver.Resolve(obj, policies)
+	matched_policy, trace := v.PolicyResolver.ResolveWithTrace(ctx, obj, policies)
 
 	if matched_policy == nil {
+		reasons := trace.Reasons()
+		if len(reasons) > 0 {
+			return admission.Denied(fmt.Sprintf("cluster access request did not match a policy: %s", strings.Join(reasons, "; ")))
+		}
 		return admission.Denied("cluster access request did not match a policy")
 	}
 
+	if req.Operation == admissionv1.Create {
+		policySpec := matched_policy.GetPolicy()
+		if err := policy.ValidateJustification(policySpec.JustificationSchema, obj.Spec.JustificationFields); err != nil {
+			return admission.Denied(fmt.Sprintf("invalid justification: %s", err))
+		}
+
+		if policySpec.TicketValidation != nil {
+			if _, err := v.TicketValidator.Validate(ctx, obj, policySpec.TicketValidation); err != nil {
+				return admission.Denied(err.Error())
+			}
+		}
+
+		// Schedules are checked against the quota at each occurrence
+		if obj.Spec.AccessSchedule != "" {
+			return admission.Allowed("valid")
+		}
+
+		// Extensions don't create a grant, so they don't count towards the quota
+		if obj.Spec.Extends != "" {
+			reason, err := validateExtension(ctx, v.client, obj, matched_policy)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if reason != "" {
+				return admission.Denied(fmt.Sprintf("grant %s can't be extended: %s", obj.Spec.Extends, reason))
+			}
+			return admission.Allowed("valid")
+		}
+
+		violation, err := policy.CheckQuota(ctx, v.client, obj, policySpec.Quota, time.Now())
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if violation != "" {
+			return admission.Denied(fmt.Sprintf("quota exceeded: %s", violation))
+		}
+	}
+
 	return admission.Allowed("valid")
 }
@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-access-antware-xyz-v1alpha1-clusteraccessschedule,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.antware.xyz,resources=clusteraccessschedules,verbs=create;update,versions=v1alpha1,name=mclusteraccessschedule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+type ClusterAccessScheduleMutator struct {
+	decoder admission.Decoder
+}
+
+func SetupClusterAccessScheduleMutatingWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(
+		"/mutate-access-antware-xyz-v1alpha1-clusteraccessschedule",
+		&admission.Webhook{Handler: &ClusterAccessScheduleMutator{
+			decoder: admission.NewDecoder(mgr.GetScheme()),
+		}},
+	)
+}
+
+func (m *ClusterAccessScheduleMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &v1alpha1.ClusterAccessSchedule{}
+
+	if err := m.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Create {
+		obj.Spec.Subject = req.UserInfo.Username
+		obj.Spec.Groups = req.UserInfo.Groups
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	"github.com/itsthatdude/jit-access-controller/internal/policy"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// validateRevocation checks the user can revoke the grant of an approved
+// request. The subject, or the delegator who requested it on their behalf, can
+// release the grant. The policy's approvers and users allowed to delete the
+// grant can revoke it.
+func validateRevocation(
+	ctx context.Context,
+	c client.Client,
+	request common.AccessRequestObject,
+	policies []common.AccessPolicyObject,
+	user authenticationv1.UserInfo,
+	isFrontend bool,
+) admission.Response {
+	spec := request.GetSpec()
+	status := request.GetStatus()
+
+	if spec.Extends != "" {
+		return admission.Denied(fmt.Sprintf("request %s extends grant %s, revoke the grant instead", request.GetName(), spec.Extends))
+	}
+	if spec.AccessSchedule != "" {
+		return admission.Denied(fmt.Sprintf("request %s approves access schedule %s, delete the schedule or its grants instead", request.GetName(), spec.AccessSchedule))
+	}
+	if status.State != v1alpha1.RequestStateApproved && status.State != v1alpha1.RequestStatePendingReview {
+		return admission.Denied(fmt.Sprintf("request %s has not been granted, only approved requests can be revoked", request.GetName()))
+	}
+
+	if isFrontend || policy.IsSelfApproval(*spec, user.Username) {
+		return admission.Allowed("valid")
+	}
+
+	if p := policy.ResolvedPolicy(request, policies); p != nil {
+		policySpec := p.GetPolicy()
+		if policy.MatchesApprovers(policy.AllApprovers(&policySpec), user.Username, user.Groups) {
+			return admission.Allowed("valid")
+		}
+	}
+
+	allowed, err := canDeleteGrant(ctx, c, request, user)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if allowed {
+		return admission.Allowed("valid")
+	}
+
+	return admission.Denied(fmt.Sprintf("user %s is not allowed to revoke the grant for request %s", user.Username, request.GetName()))
+}
+
+// canDeleteGrant returns true if the user is allowed to delete the request's
+// grant, which makes them an admin for the purposes of revocation
+func canDeleteGrant(ctx context.Context, c client.Client, request common.AccessRequestObject, user authenticationv1.UserInfo) (bool, error) {
+	resource := "accessgrants"
+	if request.GetScope() == v1alpha1.RequestScopeCluster {
+		resource = "clusteraccessgrants"
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: request.GetNamespace(),
+				Verb:      "delete",
+				Group:     v1alpha1.GroupVersion.Group,
+				Resource:  resource,
+				Name:      request.GetName(),
+			},
+		},
+	}
+
+	if err := c.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("unable to check whether %s can delete the grant: %w", user.Username, err)
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/itsthatdude/jit-access-controller/api/v1alpha1"
+	common "github.com/itsthatdude/jit-access-controller/internal/common"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestValidateRevocation(t *testing.T) {
+	ctx := context.Background()
+
+	sch := runtime.NewScheme()
+	if err := scheme.AddToScheme(sch); err != nil {
+		t.Fatalf("unable to add core scheme: %v", err)
+	}
+
+	// Only cluster admins may delete grants
+	var reviewed *authorizationv1.ResourceAttributes
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				reviewed = review.Spec.ResourceAttributes
+				review.Status.Allowed = review.Spec.User == "cluster-admin"
+				return nil
+			},
+		}).
+		Build()
+
+	policies := []common.AccessPolicyObject{
+		&v1alpha1.AccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "team-a"},
+			Spec: v1alpha1.AccessPolicySpec{
+				SubjectPolicy: v1alpha1.SubjectPolicy{
+					RequiredApprovals: 1,
+					Approvers:         []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+				},
+			},
+		},
+	}
+
+	request := func(state v1alpha1.RequestState) *v1alpha1.AccessRequest {
+		return &v1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "req", Namespace: "team-a"},
+			Spec: v1alpha1.AccessRequestSpec{
+				AccessRequestBaseSpec: v1alpha1.AccessRequestBaseSpec{Subject: "alice", Delegator: "carol", Duration: "1h"},
+			},
+			Status: v1alpha1.AccessRequestStatus{
+				State:               state,
+				ResolvedPolicy:      "debug",
+				ResolvedPolicyScope: "Namespace",
+			},
+		}
+	}
+	user := func(name string, groups ...string) authenticationv1.UserInfo {
+		return authenticationv1.UserInfo{Username: name, Groups: groups}
+	}
+
+	tests := []struct {
+		name     string
+		request  *v1alpha1.AccessRequest
+		user     authenticationv1.UserInfo
+		frontend bool
+		want     bool
+	}{
+		{name: "subject releasing the grant", request: request(v1alpha1.RequestStateApproved), user: user("alice"), want: true},
+		{name: "delegator releasing the grant", request: request(v1alpha1.RequestStateApproved), user: user("carol"), want: true},
+		{name: "policy approver", request: request(v1alpha1.RequestStateApproved), user: user("bob", "sre"), want: true},
+		{name: "user allowed to delete the grant", request: request(v1alpha1.RequestStateApproved), user: user("cluster-admin"), want: true},
+		{name: "frontend", request: request(v1alpha1.RequestStateApproved), user: user("frontend"), frontend: true, want: true},
+		{name: "non-approver without delete rights", request: request(v1alpha1.RequestStateApproved), user: user("mallory", "developers")},
+		{name: "request that wasn't granted", request: request(v1alpha1.RequestStatePending), user: user("bob", "sre")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := validateRevocation(ctx, fakeClient, tt.request, policies, tt.user, tt.frontend)
+			if resp.Allowed != tt.want {
+				t.Errorf("validateRevocation() allowed = %v, want %v: %s", resp.Allowed, tt.want, resp.Result.Message)
+			}
+		})
+	}
+
+	if reviewed == nil || reviewed.Verb != "delete" || reviewed.Resource != "accessgrants" || reviewed.Namespace != "team-a" {
+		t.Errorf("expected delete rights on the request's grant to be checked, got %+v", reviewed)
+	}
+}